@@ -0,0 +1,71 @@
+package imdblit
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Aliases is a registry of alternate titles for works, such as translated
+// titles, e.g. "Der Prozess" and "The Trial". All titles registered together
+// are aliases of each other, and when used with WithAliases, an adaptation
+// search for any one of them will also search for the others.
+//
+// Aliases is safe for concurrent use.
+type Aliases struct {
+	registry
+}
+
+// NewAliases returns an empty alias registry.
+func NewAliases() *Aliases {
+	return &Aliases{registry: newRegistry(aliasKey)}
+}
+
+// LoadAliases returns a new alias registry, loaded from JSON, as described in Load.
+func LoadAliases(r io.Reader) (*Aliases, error) {
+	a := NewAliases()
+	if err := a.Load(r); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Load adds the aliases from a JSON object, where each key is a title, and
+// its value the list of aliases for it:
+//
+//	{"The Trial": ["Der Prozess", "Le Procès"]}
+func (a *Aliases) Load(r io.Reader) error {
+	if err := a.load(r); err != nil {
+		return fmt.Errorf("loading aliases: %w", err)
+	}
+	return nil
+}
+
+// Add registers the aliases for the title. If any of the titles already have
+// aliases, all of them are merged together.
+func (a *Aliases) Add(title string, aliases ...string) {
+	a.add(append([]string{title}, aliases...)...)
+}
+
+// Titles returns the title followed by all of its aliases, in the order they
+// were registered.
+func (a *Aliases) Titles(title string) []string {
+	if a == nil {
+		return []string{title}
+	}
+	return a.names(title)
+}
+
+// aliasKey ignores case, punctuation, and the word "the", so "Trial, The"
+// and "The Trial" are the same title.
+func aliasKey(title string) string {
+	return strings.Join(words(title), " ")
+}
+
+// WithAliases expands the title of an adaptation search to include all its
+// aliases. The Match of each result gives the alias that matched, if any.
+func WithAliases(a *Aliases) SearchOption {
+	return func(o *searchOptions) {
+		o.aliases = a
+	}
+}
@@ -0,0 +1,72 @@
+package imdblit_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	imdb "github.com/mrcook/imdblit"
+)
+
+var aliasesText = imdbText[:strings.Index(imdbText, "MOVI:")] + `MOVI: Le procès (1962)
+
+NOVL: Kafka, Franz. "Der Prozess"
+
+-------------------------------------------------------------------------------
+MOVI: The Trial (1993)
+
+ADPT: Kafka, Franz. "The Trial"
+`
+
+func TestAliases(t *testing.T) {
+	aliases, err := imdb.LoadAliases(strings.NewReader(`{"The Trial": ["Der Prozess"], "Le Procès": ["Trial, The"]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	titles := aliases.Titles("der prozess")
+	if len(titles) != 3 || titles[0] != "der prozess" {
+		t.Fatalf("expected the title and 2 aliases, got %v", titles)
+	}
+
+	if titles := aliases.Titles("Amerika"); len(titles) != 1 {
+		t.Errorf("expected no aliases, got %v", titles)
+	}
+
+	if _, err := imdb.LoadAliases(strings.NewReader(`["The Trial"]`)); err == nil {
+		t.Errorf("expected invalid JSON to return an error")
+	}
+}
+
+func TestMovieAdaptationsWithAliases(t *testing.T) {
+	aliases := imdb.NewAliases()
+	aliases.Add("The Trial", "Der Prozess")
+
+	db := imdb.NewIMDB(bytes.NewBufferString(aliasesText))
+	results, err := db.FindMovieAdaptationMatches("The Trial", "Franz Kafka", imdb.WithAliases(aliases))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 movies to be found, got %d", len(results))
+	}
+	if results[0].Match.Alias != "" {
+		t.Errorf("expected the query title to match, got alias '%s'", results[0].Match.Alias)
+	}
+	if results[1].Match.Alias != "Der Prozess" {
+		t.Errorf("expected the alias to match, got '%s'", results[1].Match.Alias)
+	}
+
+	store, err := imdb.NewStore(imdb.NewIMDB(bytes.NewBufferString(aliasesText)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	movies, err := store.FindMovieAdaptations("Der Prozess", "Kafka", imdb.WithAliases(aliases))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(movies) != 2 {
+		t.Errorf("expected 2 movies to be found in the store, got %d", len(movies))
+	}
+}
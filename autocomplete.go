@@ -0,0 +1,181 @@
+package imdblit
+
+import (
+	"sort"
+	"strings"
+)
+
+// SuggestionKind is the kind of text an autocomplete suggestion is for.
+type SuggestionKind int
+
+// List of all the suggestion kinds.
+const (
+	SuggestBookTitle  SuggestionKind = iota // title of a book entry (ADPT, BOOK, NOVL)
+	SuggestAuthor                           // author of a book entry, one per author-role contributor
+	SuggestMovieTitle                       // title from the MOVI entry
+)
+
+// Suggestion is an autocomplete suggestion, along with how often it occurs.
+type Suggestion struct {
+	Text  string // as given by the first entry it occurs in
+	Kind  SuggestionKind
+	Count int // number of entries it occurs in
+}
+
+// completions holds every suggestion, along with a list of their normalised
+// keys sorted for prefix lookups.
+type completions struct {
+	suggestions []Suggestion
+	keys        []completionKey
+}
+
+type completionKey struct {
+	key        string
+	suggestion int
+}
+
+// Autocomplete returns up to n suggestions of book titles, authors, and movie
+// titles starting with the prefix, most frequent first. When kinds are given,
+// only suggestions of those kinds are returned.
+//
+// The text is split into words the same way as for the Store indexes, so
+// case, accents, punctuation, and the word "the" are ignored, e.g. "Last of the Mohicans, The"
+// is found using "the last of". Authors written as "Austen, Jane" can also be
+// found using "Jane Austen".
+//
+// The autocomplete index is built on first use.
+func (s *Store) Autocomplete(prefix string, n int, kinds ...SuggestionKind) []Suggestion {
+	s.completionsOnce.Do(s.buildCompletions)
+
+	prefix = normaliseCompletion(prefix)
+	if len(prefix) == 0 || n <= 0 {
+		return nil
+	}
+
+	keys := s.completions.keys
+	start := sort.Search(len(keys), func(i int) bool {
+		return keys[i].key >= prefix
+	})
+
+	var found []int
+	seen := map[int]bool{}
+	for _, k := range keys[start:] {
+		if !strings.HasPrefix(k.key, prefix) {
+			break
+		}
+		if seen[k.suggestion] || !hasKind(kinds, s.completions.suggestions[k.suggestion].Kind) {
+			continue
+		}
+		seen[k.suggestion] = true
+		found = append(found, k.suggestion)
+	}
+
+	// suggestions are numbered in the order first seen, which breaks any ties
+	suggestions := s.completions.suggestions
+	sort.Slice(found, func(i, j int) bool {
+		a, b := suggestions[found[i]], suggestions[found[j]]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return found[i] < found[j]
+	})
+	if len(found) > n {
+		found = found[:n]
+	}
+
+	results := make([]Suggestion, len(found))
+	for i, f := range found {
+		results[i] = suggestions[f]
+	}
+
+	return results
+}
+
+func (s *Store) buildCompletions() {
+	c := &s.completions
+	ids := map[completionID]int{}
+	keys := map[completionKey]bool{}
+
+	addKey := func(key string, suggestion int) {
+		k := completionKey{key: key, suggestion: suggestion}
+		if len(key) > 0 && !keys[k] {
+			keys[k] = true
+			c.keys = append(c.keys, k)
+		}
+	}
+
+	add := func(text string, kind SuggestionKind) {
+		key := normaliseCompletion(text)
+		if len(key) == 0 {
+			return
+		}
+
+		// authors are the same whatever the order of their names
+		id := completionID{key: key, kind: kind}
+		if kind == SuggestAuthor {
+			id.key = pseudonymKey(text)
+		}
+
+		i, ok := ids[id]
+		if !ok {
+			i = len(c.suggestions)
+			ids[id] = i
+			c.suggestions = append(c.suggestions, Suggestion{Text: strings.TrimSpace(text), Kind: kind})
+		}
+		c.suggestions[i].Count++
+
+		addKey(key, i)
+		if kind == SuggestAuthor {
+			addKey(reverseAuthor(text), i)
+		}
+	}
+
+	for _, mov := range s.movies {
+		add(mov.Title, SuggestMovieTitle)
+		for _, b := range mov.BookEntries() {
+			add(b.Title, SuggestBookTitle)
+			for _, name := range b.AuthorNames() {
+				add(name, SuggestAuthor)
+			}
+		}
+	}
+
+	sort.Slice(c.keys, func(i, j int) bool {
+		if c.keys[i].key != c.keys[j].key {
+			return c.keys[i].key < c.keys[j].key
+		}
+		return c.keys[i].suggestion < c.keys[j].suggestion
+	})
+}
+
+type completionID struct {
+	key  string
+	kind SuggestionKind
+}
+
+func hasKind(kinds []SuggestionKind, kind SuggestionKind) bool {
+	if len(kinds) == 0 {
+		return true
+	}
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// normaliseCompletion returns the words of the text, as used by the Store indexes.
+func normaliseCompletion(text string) string {
+	return strings.Join(words(text), " ")
+}
+
+// reverseAuthor returns the normalised author name, with a "Last, First" name
+// turned around to "First Last".
+func reverseAuthor(author string) string {
+	i := strings.Index(author, ",")
+	if i < 0 {
+		return ""
+	}
+	return normaliseCompletion(author[i+1:] + " " + author[:i])
+}
@@ -0,0 +1,53 @@
+package imdblit_test
+
+import (
+	"bytes"
+	"testing"
+
+	imdb "github.com/mrcook/imdblit"
+)
+
+func TestAutocomplete(t *testing.T) {
+	store, err := imdb.NewStore(imdb.NewIMDB(bytes.NewBufferString(worksText)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testItems := []struct {
+		prefix      string
+		kinds       []imdb.SuggestionKind
+		suggestions []string
+	}{
+		{prefix: "em", suggestions: []string{"Emma", "Emma"}},
+		{prefix: "EM", kinds: []imdb.SuggestionKind{imdb.SuggestMovieTitle}, suggestions: []string{"Emma"}},
+		{prefix: "jane au", suggestions: []string{"Austen, Jane"}},
+		{prefix: "austen", suggestions: []string{"Austen, Jane"}},
+		{prefix: "the pers", suggestions: []string{"Persuasion", "Persuasion"}},
+		{prefix: "ÉMMA", kinds: []imdb.SuggestionKind{imdb.SuggestBookTitle}, suggestions: []string{"Emma"}},
+		{prefix: "the"},
+		{prefix: ""},
+	}
+
+	for i, item := range testItems {
+		suggestions := store.Autocomplete(item.prefix, 5, item.kinds...)
+		if len(suggestions) != len(item.suggestions) {
+			t.Errorf("(#%d) expected %d suggestions, got %d: %+v", i, len(item.suggestions), len(suggestions), suggestions)
+			continue
+		}
+		for j, s := range suggestions {
+			if s.Text != item.suggestions[j] {
+				t.Errorf("(#%d) expected suggestion '%s', got '%s'", i, item.suggestions[j], s.Text)
+			}
+		}
+	}
+
+	suggestions := store.Autocomplete("e", 1)
+	if len(suggestions) != 1 || suggestions[0].Kind != imdb.SuggestBookTitle || suggestions[0].Count != 3 {
+		t.Errorf("expected the most frequent suggestion, got %+v", suggestions)
+	}
+
+	suggestions = store.Autocomplete("a", 5, imdb.SuggestAuthor)
+	if len(suggestions) != 1 || suggestions[0].Count != 4 {
+		t.Errorf("expected authors to be merged whatever the name order, got %+v", suggestions)
+	}
+}
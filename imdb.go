@@ -7,7 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
-	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +22,7 @@ const recordDivider = "---------------------------------------------------------
 type IMDB struct {
 	r io.Reader
 
+	crc          uint32
 	createdOn    time.Time
 	totalRecords int
 }
@@ -31,6 +32,12 @@ func NewIMDB(r io.Reader) *IMDB {
 	return &IMDB{r: bufio.NewReader(r)}
 }
 
+// DatabaseCRC will contain the CRC from the header of the DB file, once the
+// .list has been parsed.
+func (db *IMDB) DatabaseCRC() uint32 {
+	return db.crc
+}
+
 // DatabaseCreatedOn will contain the datetime that the DB file was generated,
 // once the .list has been parsed.
 func (db *IMDB) DatabaseCreatedOn() time.Time {
@@ -44,39 +51,21 @@ func (db *IMDB) TotalRecordCount() int {
 }
 
 // ExtractAll processes the DB and returns all movies and associated data.
-func (db *IMDB) ExtractAll() ([]movie.Movie, error) {
+// Only the filtering options, such as WithSuspended, are used; the movies are
+// always returned in file order.
+func (db *IMDB) ExtractAll(opts ...SearchOption) ([]movie.Movie, error) {
 	var movies []movie.Movie
 
-	scanner := bufio.NewScanner(db.r)
-	if err := db.readDBHeader(scanner); err != nil {
-		return movies, err
+	var accept func(*movie.Movie) bool
+	if len(opts) > 0 {
+		accept = newSearchOptions(opts).accept
 	}
 
-	recordText := ""
-
-	done := false
-	for !done {
-		done = !scanner.Scan()
-		line := db.decodeWindows1252(scanner.Text())
-
-		// the very first divider in the list, ignore it
-		if line == recordDivider && len(recordText) == 0 {
-			continue
-		}
-
-		// if a divider for the next movie entry is reached, add to the slice
-		if done || line == recordDivider {
-			mov := movie.Movie{}
-			movie.Unmarshall(recordText, &mov)
-			movies = append(movies, mov)
-			recordText = ""
-			db.totalRecords++
-		} else {
-			recordText += line + "\n"
-		}
-	}
+	err := db.scan(movie.Unmarshall, accept, func(_ int, mov movie.Movie) {
+		movies = append(movies, mov)
+	})
 
-	return movies, nil
+	return movies, err
 }
 
 // FindMovieAdaptations processes the DB and returns movies that are
@@ -84,15 +73,92 @@ func (db *IMDB) ExtractAll() ([]movie.Movie, error) {
 //
 // The search will only parse the book types: ADPT, BOOK, and NOVL, which will
 // speed up the processing considerably.
-func (db *IMDB) FindMovieAdaptations(title, author string) ([]movie.Movie, error) {
-	var movies []movie.Movie
+//
+// Movies are returned newest first, unless another order is given using WithSort.
+func (db *IMDB) FindMovieAdaptations(title, author string, opts ...SearchOption) ([]movie.Movie, error) {
+	results, err := db.FindMovieAdaptationMatches(title, author, opts...)
+	if err != nil {
+		return nil, err
+	}
 
+	return resultMovies(results), nil
+}
+
+// FindMovieAdaptationMatches is the same as FindMovieAdaptations, but each
+// movie is returned with a report of the entry that matched.
+func (db *IMDB) FindMovieAdaptationMatches(title, author string, opts ...SearchOption) ([]Result, error) {
+	return findMovieAdaptations(db.each(movie.UnmarshallBooks), title, author, opts)
+}
+
+// FindAdaptationsByAuthor processes the DB and returns all movies that are
+// adaptations of any work by the given author, grouped by the work title.
+// Works are sorted by their normalised title, and a movie adapting more than
+// one work by the author is included in each of them. Any pagination is
+// applied to the works, while the sort order applies to the movies of each work.
+func (db *IMDB) FindAdaptationsByAuthor(author string, opts ...SearchOption) ([]AdaptedWork, error) {
+	return findAdaptationsByAuthor(db.each(movie.UnmarshallBooks), author, opts)
+}
+
+// FindByISBN processes the DB and returns movies with a book entry (ADPT,
+// BOOK, NOVL) with the given ISBN, or with a publication entry having it in
+// place of an ISSN. The isbn may be an ISBN-10 or ISBN-13, with or without hyphens,
+// and will match entries using either form.
+func (db *IMDB) FindByISBN(isbn string, opts ...SearchOption) ([]Result, error) {
+	return findByISBN(db.each(movie.Unmarshall), isbn, opts)
+}
+
+// FindByQuery processes the DB and returns all movies matching the query,
+// which is written in the query language described in the query package, e.g.
+// `author:"Austen" AND year:1990..2010 AND NOT tv:true AND type:NOVL`.
+// Movies are returned in the order they appear in the DB, unless another order
+// is given using WithSort.
+func (db *IMDB) FindByQuery(text string, opts ...SearchOption) ([]movie.Movie, error) {
+	return findByQuery(db.each(movie.Unmarshall), text, opts)
+}
+
+// FindPublications processes the DB and returns movies with publication
+// entries (CRIT, ESSY, IVIW, OTHR, PROT, SCRP) matching the query, along
+// with those entries.
+func (db *IMDB) FindPublications(q movie.PublicationQuery, opts ...SearchOption) ([]PublicationResult, error) {
+	return findPublications(db.each(movie.Unmarshall), q, opts)
+}
+
+// GroupSeries processes the DB and returns all TV series, with their episode
+// records grouped by season, and the literary sources of all their records
+// merged together. Series are sorted by title, unless another order is given
+// using WithSort, and any pagination is applied to the series.
+func (db *IMDB) GroupSeries(opts ...SearchOption) ([]Series, error) {
+	return groupSeries(db.each(movie.UnmarshallBooks), opts)
+}
+
+// ClusterWorks processes the DB and returns all the literary works adapted by
+// the movies, clustering the book entries (ADPT, BOOK, NOVL) by their
+// normalised title and author, and linking each work to the movies adapting
+// it. Works are sorted by their normalised title and author. Any pagination is
+// applied to the works, while the sort order applies to the movies of each work.
+func (db *IMDB) ClusterWorks(opts ...SearchOption) ([]Work, error) {
+	return clusterWorks(db.each(movie.UnmarshallBooks), opts)
+}
+
+// each returns a func that scans the DB, unmarshalling each record with the
+// given func.
+func (db *IMDB) each(unmarshall func(string, *movie.Movie)) eachMovie {
+	return func(accept func(*movie.Movie) bool, fn func(pos int, mov movie.Movie)) error {
+		return db.scan(unmarshall, accept, fn)
+	}
+}
+
+// scan reads each record from the DB, passing the movie unmarshalled from it,
+// and the position of the record, to fn. When accept is given, only records
+// with a MOVI entry it accepts are fully unmarshalled and passed to fn.
+func (db *IMDB) scan(unmarshall func(string, *movie.Movie), accept func(*movie.Movie) bool, fn func(pos int, mov movie.Movie)) error {
 	scanner := bufio.NewScanner(db.r)
 	if err := db.readDBHeader(scanner); err != nil {
-		return movies, err
+		return err
 	}
 
 	recordText := ""
+	position := 0
 
 	done := false
 	for !done {
@@ -104,29 +170,32 @@ func (db *IMDB) FindMovieAdaptations(title, author string) ([]movie.Movie, error
 			continue
 		}
 
-		// if a divider for the next movie entry is reached, check if the
-		// current entry is an adaptation and add to list.
+		// if a divider for the next movie entry is reached, process the current entry
 		if done || line == recordDivider {
-			mov := movie.Movie{}
-			movie.UnmarshallBooks(recordText, &mov)
-
-			if mov.IsAdaptation(title, author) {
-				movies = append(movies, mov)
+			if db.accepted(recordText, accept) {
+				mov := movie.Movie{}
+				unmarshall(recordText, &mov)
+				fn(position, mov)
 			}
 
 			recordText = ""
 			db.totalRecords++
+			position++
 		} else {
 			recordText += line + "\n"
 		}
 	}
 
-	// quick reverse sort
-	sort.Slice(movies, func(i, j int) bool {
-		return movies[i].Year > movies[j].Year
-	})
+	return nil
+}
 
-	return movies, nil
+func (db *IMDB) accepted(recordText string, accept func(*movie.Movie) bool) bool {
+	if accept == nil {
+		return true
+	}
+	mov := movie.Movie{}
+	movie.UnmarshallTitle(recordText, &mov)
+	return accept(&mov)
 }
 
 // Reads the header section of the database file, reading the created on datetime,
@@ -139,6 +208,14 @@ func (db *IMDB) readDBHeader(scanner *bufio.Scanner) error {
 
 		line := scanner.Text()
 
+		if strings.HasPrefix(line, "CRC: ") {
+			// NOTE: a truncated header may have no CRC value
+			if fields := strings.Fields(line); len(fields) > 1 {
+				crc, _ := strconv.ParseUint(strings.TrimPrefix(fields[1], "0x"), 16, 32)
+				db.crc = uint32(crc)
+			}
+		}
+
 		if strings.Contains(line, " Date: ") {
 			parts := strings.Split(line, " Date: ")
 			date := strings.TrimSpace(parts[len(parts)-1])
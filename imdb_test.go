@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	imdb "github.com/mrcook/imdblit"
+	"github.com/mrcook/imdblit/movie"
 )
 
 // TODO: encode as Windows 1252, as that's the encoding of the official IMDB literature.list file.
@@ -91,6 +92,162 @@ func TestMovieAdaptations(t *testing.T) {
 	}
 }
 
+func TestMovieAdaptationsWithOptions(t *testing.T) {
+	testItems := []struct {
+		opts  []imdb.SearchOption
+		years []int
+	}{
+		{opts: []imdb.SearchOption{imdb.WithTV(false)}, years: []int{1983}},
+		{opts: []imdb.SearchOption{imdb.WithTV(true)}, years: []int{2007}},
+		{opts: []imdb.SearchOption{imdb.WithYears(1990, 0)}, years: []int{2007}},
+		{opts: []imdb.SearchOption{imdb.WithYears(0, 1990)}, years: []int{1983}},
+		{opts: []imdb.SearchOption{imdb.WithYears(1984, 2006)}},
+		{opts: []imdb.SearchOption{imdb.WithSeries(false), imdb.WithEpisodeInfo(false)}, years: []int{2007, 1983}},
+		{opts: []imdb.SearchOption{imdb.WithSeries(true)}},
+		{opts: []imdb.SearchOption{imdb.WithKinds(movie.KindTVMovie)}, years: []int{2007}},
+		{opts: []imdb.SearchOption{imdb.WithKinds(movie.KindFeature, movie.KindVideo)}, years: []int{1983}},
+	}
+
+	for i, item := range testItems {
+		db := imdb.NewIMDB(bytes.NewBufferString(imdbText))
+
+		movies, err := db.FindMovieAdaptations("Mansfield Park", "Jane Austen", item.opts...)
+		if err != nil {
+			t.Fatalf("(#%d) unexpected error: %s", i, err)
+		}
+		if len(movies) != len(item.years) {
+			t.Fatalf("(#%d) expected %d movies to be found, got %d", i, len(item.years), len(movies))
+		}
+		for j, year := range item.years {
+			if movies[j].Year != year {
+				t.Errorf("(#%d) unexpected movie year, got: %d", i, movies[j].Year)
+			}
+		}
+	}
+
+	db := imdb.NewIMDB(bytes.NewBufferString(imdbText))
+	movies, err := db.FindMovieAdaptations("Last of the Mohicans", "Cooper", imdb.WithSeries(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(movies) != 1 {
+		t.Errorf("expected the series to be found, got %d movies", len(movies))
+	}
+}
+
+func TestMovieAdaptationMatches(t *testing.T) {
+	file := bytes.NewBuffer([]byte(imdbText)) // Fake a file read
+	db := imdb.NewIMDB(file)
+
+	results, err := db.FindMovieAdaptationMatches("The Last of the Mohicans", "James Cooper")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 movie to be found, got %d", len(results))
+	}
+
+	r := results[0]
+	if r.Title != "The Last of the Mohicans" {
+		t.Errorf("unexpected movie title, got: %s", r.Title)
+	}
+	if r.Match.Entry != movie.NOVL || r.Match.Index != 0 {
+		t.Errorf("unexpected matching entry, got: %s #%d", r.Match.Entry, r.Match.Index)
+	}
+	if r.Match.Title != "last of mohicans, the" {
+		t.Errorf("unexpected normalised title, got: '%s'", r.Match.Title)
+	}
+	if r.Match.QueryAuthor != "james cooper" {
+		t.Errorf("unexpected normalised query author, got: '%s'", r.Match.QueryAuthor)
+	}
+}
+
+func TestFindAdaptationsByAuthor(t *testing.T) {
+	file := bytes.NewBuffer([]byte(imdbText)) // Fake a file read
+	db := imdb.NewIMDB(file)
+
+	works, err := db.FindAdaptationsByAuthor("Austen")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(works) != 1 {
+		t.Fatalf("expected 1 work to be found, got %d", len(works))
+	}
+	if works[0].Title != "Mansfield Park" {
+		t.Errorf("unexpected work title, got: %s", works[0].Title)
+	}
+	if len(works[0].Movies) != 2 {
+		t.Fatalf("expected 2 movies to be found, got %d", len(works[0].Movies))
+	}
+	if works[0].Movies[0].Year != 2007 {
+		t.Errorf("unexpected movie year, got: %d", works[0].Movies[0].Year)
+	}
+}
+
+func TestFindByISBN(t *testing.T) {
+	file := bytes.NewBuffer([]byte(imdbText)) // Fake a file read
+	db := imdb.NewIMDB(file)
+
+	results, err := db.FindByISBN("978-0-553-21329-4")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 movie to be found, got %d", len(results))
+	}
+	if results[0].Title != "The Last of the Mohicans" {
+		t.Errorf("unexpected movie title, got: %s", results[0].Title)
+	}
+
+	if _, err := imdb.NewIMDB(bytes.NewBufferString(imdbText)).FindByISBN("0553213297"); err == nil {
+		t.Errorf("expected an invalid ISBN to return an error")
+	}
+}
+
+func TestFindByQuery(t *testing.T) {
+	file := bytes.NewBuffer([]byte(imdbText)) // Fake a file read
+	db := imdb.NewIMDB(file)
+
+	movies, err := db.FindByQuery(`author:Austen AND NOT tv:true`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(movies) != 1 {
+		t.Fatalf("expected 1 movie to be found, got %d", len(movies))
+	}
+	if movies[0].Year != 1983 {
+		t.Errorf("unexpected movie year, got: %d", movies[0].Year)
+	}
+
+	if _, err := imdb.NewIMDB(bytes.NewBufferString(imdbText)).FindByQuery(`author:`); err == nil {
+		t.Errorf("expected an invalid query to return an error")
+	}
+}
+
+func TestFindPublications(t *testing.T) {
+	file := bytes.NewBuffer([]byte(imdbText)) // Fake a file read
+	db := imdb.NewIMDB(file)
+
+	results, err := db.FindPublications(movie.PublicationQuery{Name: "The Independent"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(results) != 1 {
+		t.Fatalf("expected 1 movie to be found, got %d", len(results))
+	}
+	if results[0].Title != "Mansfield Park" {
+		t.Errorf("unexpected movie title, got: %s", results[0].Title)
+	}
+	if len(results[0].Entries) != 1 || results[0].Entries[0].Entry != movie.PROT {
+		t.Errorf("expected the PROT entry to be returned, got: %v", results[0].Entries)
+	}
+}
+
 func TestIMDB_ExtractAll(t *testing.T) {
 	file := bytes.NewBuffer([]byte(imdbText)) // Fake a file read
 	db := imdb.NewIMDB(file)
@@ -120,3 +277,84 @@ func TestIMDB_ExtractAll(t *testing.T) {
 		t.Errorf("unexpected movie year, got: %d", mov.Year)
 	}
 }
+
+func TestIMDB_ExtractAllWithoutSuspended(t *testing.T) {
+	text := imdbText + `
+-------------------------------------------------------------------------------
+MOVI: "Mansfield Park" (1986) {(#1.1)} {{SUSPENDED}}
+
+NOVL: Austen, Jane. "Mansfield Park"
+`
+	db := imdb.NewIMDB(bytes.NewBufferString(text))
+	movies, err := db.ExtractAll(imdb.WithSuspended(false))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(movies) != 5 {
+		t.Errorf("expected 5 movies to be found, got %d", len(movies))
+	}
+
+	db = imdb.NewIMDB(bytes.NewBufferString(text))
+	results, err := db.FindMovieAdaptationMatches("Mansfield Park", "Austen", imdb.WithSuspended(true))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 1 || !results[0].Suspended || results[0].EpisodeNumber != 1 {
+		t.Errorf("expected only the suspended episode to be found, got %d movies", len(results))
+	}
+}
+
+func TestMovieAdaptationsSortingAndPagination(t *testing.T) {
+	testItems := []struct {
+		opts  []imdb.SearchOption
+		years []int
+	}{
+		{opts: nil, years: []int{2007, 1983}},
+		{opts: []imdb.SearchOption{imdb.WithSort(imdb.SortYearAsc)}, years: []int{1983, 2007}},
+		{opts: []imdb.SearchOption{imdb.WithSort(imdb.SortFileOrder)}, years: []int{1983, 2007}},
+		{opts: []imdb.SearchOption{imdb.WithPagination(1, 0)}, years: []int{1983}},
+		{opts: []imdb.SearchOption{imdb.WithPagination(0, 1)}, years: []int{2007}},
+		{opts: []imdb.SearchOption{imdb.WithSort(imdb.SortYearAsc), imdb.WithPagination(1, 5)}, years: []int{2007}},
+		{opts: []imdb.SearchOption{imdb.WithPagination(5, 1)}},
+	}
+
+	for i, item := range testItems {
+		db := imdb.NewIMDB(bytes.NewBufferString(imdbText))
+
+		movies, err := db.FindMovieAdaptations("Mansfield Park", "Jane Austen", item.opts...)
+		if err != nil {
+			t.Fatalf("(#%d) unexpected error: %s", i, err)
+		}
+		if len(movies) != len(item.years) {
+			t.Fatalf("(#%d) expected %d movies to be found, got %d", i, len(item.years), len(movies))
+		}
+		for j, year := range item.years {
+			if movies[j].Year != year {
+				t.Errorf("(#%d) unexpected movie year, got: %d", i, movies[j].Year)
+			}
+		}
+	}
+}
+
+func TestQuerySortedByTitle(t *testing.T) {
+	db := imdb.NewIMDB(bytes.NewBufferString(imdbText))
+
+	movies, err := db.FindByQuery("year:..2010", imdb.WithSort(imdb.SortTitle))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	titles := []string{"Dissonances", "Mansfield Park", "Mansfield Park", "Mansion of the Doomed", "The Last of the Mohicans"}
+	if len(movies) != len(titles) {
+		t.Fatalf("expected %d movies to be found, got %d", len(titles), len(movies))
+	}
+	for i, title := range titles {
+		if movies[i].Title != title {
+			t.Errorf("(#%d) unexpected movie title, got: %s", i, movies[i].Title)
+		}
+	}
+	// equal titles are kept in file order
+	if movies[1].Year != 1983 || movies[2].Year != 2007 {
+		t.Errorf("expected equal titles to be in file order")
+	}
+}
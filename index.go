@@ -0,0 +1,217 @@
+package imdblit
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mrcook/imdblit/movie"
+)
+
+// ErrInvalidIndex is returned when reading an index file that is corrupt, or
+// was written with an unsupported version of the format.
+var ErrInvalidIndex = errors.New("invalid index file")
+
+// indexVersion must be incremented whenever the index format, or the data
+// stored in it (e.g. the movie types), changes.
+const indexVersion = 16
+
+var indexMagic = [8]byte{'I', 'M', 'D', 'B', 'L', 'I', 'T', 0}
+
+// indexHeader is written uncompressed at the start of an index file, and
+// ties the index to the DB it was built from.
+type indexHeader struct {
+	Magic     [8]byte
+	Version   uint16
+	CRC       uint32 // CRC from the DB header
+	CreatedOn int64  // Unix time the DB was generated, from the DB header
+	Length    uint64 // length of the payload
+	Checksum  uint32 // CRC-32 (IEEE) of the payload
+}
+
+// indexPayload is gob encoded, then gzip compressed, after the header.
+type indexPayload struct {
+	Movies          []movie.Movie
+	MovieTitles     map[string][]int
+	BookTitles      map[string][]int
+	Authors         map[string][]int
+	PhoneticAuthors map[string][]int
+	ISBNs           map[string][]int
+	Publications    map[string][]int
+}
+
+// WriteIndex writes the Store, along with its indexes, in a compact binary
+// format which can be loaded with ReadStore.
+func (s *Store) WriteIndex(w io.Writer) error {
+	var payload bytes.Buffer
+
+	zw := gzip.NewWriter(&payload)
+	err := gob.NewEncoder(zw).Encode(indexPayload{
+		Movies:          s.movies,
+		MovieTitles:     s.movieTitles,
+		BookTitles:      s.bookTitles,
+		Authors:         s.authors,
+		PhoneticAuthors: s.phoneticAuthors,
+		ISBNs:           s.isbns,
+		Publications:    s.publications,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding index: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("compressing index: %w", err)
+	}
+
+	header := indexHeader{
+		Magic:     indexMagic,
+		Version:   indexVersion,
+		CRC:       s.crc,
+		CreatedOn: s.createdOn.Unix(),
+		Length:    uint64(payload.Len()),
+		Checksum:  crc32.ChecksumIEEE(payload.Bytes()),
+	}
+	if err := binary.Write(w, binary.BigEndian, header); err != nil {
+		return err
+	}
+	_, err = w.Write(payload.Bytes())
+
+	return err
+}
+
+// ReadStore loads a Store from an index written by WriteIndex. An error
+// wrapping ErrInvalidIndex is returned when the index is corrupt, or is
+// from a different version of this package.
+func ReadStore(r io.Reader) (*Store, error) {
+	var header indexHeader
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, fmt.Errorf("%w: reading header: %s", ErrInvalidIndex, err)
+	}
+	if header.Magic != indexMagic {
+		return nil, fmt.Errorf("%w: not an index file", ErrInvalidIndex)
+	}
+	if header.Version != indexVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidIndex, header.Version)
+	}
+
+	// a corrupt length must not cause a huge allocation, so read up to it
+	payload, err := io.ReadAll(io.LimitReader(r, int64(header.Length)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading payload: %s", ErrInvalidIndex, err)
+	}
+	if uint64(len(payload)) != header.Length {
+		return nil, fmt.Errorf("%w: payload is truncated", ErrInvalidIndex)
+	}
+	if crc32.ChecksumIEEE(payload) != header.Checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidIndex)
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("%w: decompressing payload: %s", ErrInvalidIndex, err)
+	}
+	var data indexPayload
+	if err := gob.NewDecoder(zr).Decode(&data); err != nil {
+		return nil, fmt.Errorf("%w: decoding payload: %s", ErrInvalidIndex, err)
+	}
+
+	return &Store{
+		movies:          data.Movies,
+		crc:             header.CRC,
+		createdOn:       time.Unix(header.CreatedOn, 0).UTC(),
+		movieTitles:     nonNilIndex(data.MovieTitles),
+		bookTitles:      nonNilIndex(data.BookTitles),
+		authors:         nonNilIndex(data.Authors),
+		phoneticAuthors: nonNilIndex(data.PhoneticAuthors),
+		isbns:           nonNilIndex(data.ISBNs),
+		publications:    nonNilIndex(data.Publications),
+	}, nil
+}
+
+// gob does not encode empty maps, so they are decoded as nil.
+func nonNilIndex(idx map[string][]int) index {
+	if idx == nil {
+		return index{}
+	}
+	return idx
+}
+
+// OpenStore loads a Store from the index file, as long as it was built from
+// the current version of the literature.list file, as determined by the CRC
+// and date in the list header. When the index is missing, invalid, or out of
+// date, or the list header has neither a CRC nor a date to compare, the Store
+// is built from the list and the index file (re)written.
+func OpenStore(listPath, indexPath string) (*Store, error) {
+	list, err := os.Open(listPath)
+	if err != nil {
+		return nil, err
+	}
+	defer list.Close()
+
+	db := NewIMDB(list)
+	if err := db.readDBHeader(bufio.NewScanner(db.r)); err != nil {
+		return nil, err
+	}
+
+	// without a CRC or date, there is no way to tell whether the list changed
+	versioned := db.crc != 0 || !db.createdOn.IsZero()
+	if s, err := readStoreFile(indexPath); versioned && err == nil && s.crc == db.crc && s.createdOn.Equal(db.createdOn) {
+		return s, nil
+	}
+
+	if _, err := list.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	s, err := NewStore(NewIMDB(list))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.writeIndexFile(indexPath); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func readStoreFile(path string) (*Store, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadStore(bufio.NewReader(f))
+}
+
+// writeIndexFile writes to a temporary file first, so that an existing index
+// is only replaced once the new one is complete.
+func (s *Store) writeIndexFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	w := bufio.NewWriter(tmp)
+	if err := s.WriteIndex(w); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
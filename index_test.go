@@ -0,0 +1,148 @@
+package imdblit_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	imdb "github.com/mrcook/imdblit"
+)
+
+func TestStore_WriteIndex(t *testing.T) {
+	store := newTestStore(t)
+
+	var buf bytes.Buffer
+	if err := store.WriteIndex(&buf); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	loaded, err := imdb.ReadStore(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if loaded.Len() != 5 {
+		t.Errorf("expected 5 movies to be loaded, got %d", loaded.Len())
+	}
+	if loaded.DatabaseCRC() != 0x527C5E79 {
+		t.Errorf("unexpected CRC, got %X", loaded.DatabaseCRC())
+	}
+	if !loaded.DatabaseCreatedOn().Equal(store.DatabaseCreatedOn()) {
+		t.Errorf("unexpected created on date, got %s", loaded.DatabaseCreatedOn())
+	}
+
+	movies, err := loaded.FindMovieAdaptations("Mansfield Park", "Jane Austen")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(movies) != 2 {
+		t.Errorf("expected 2 movies to be found, got %d", len(movies))
+	}
+
+	// corrupt the last byte of the payload
+	corrupt := buf.Bytes()
+	corrupt[len(corrupt)-1]++
+	if _, err := imdb.ReadStore(bytes.NewReader(corrupt)); !errors.Is(err, imdb.ErrInvalidIndex) {
+		t.Errorf("expected an invalid index error, got %v", err)
+	}
+
+	if _, err := imdb.ReadStore(strings.NewReader("not an index file")); !errors.Is(err, imdb.ErrInvalidIndex) {
+		t.Errorf("expected an invalid index error, got %v", err)
+	}
+}
+
+func TestOpenStore(t *testing.T) {
+	dir := t.TempDir()
+	listPath := filepath.Join(dir, "literature.list")
+	indexPath := filepath.Join(dir, "literature.idx")
+
+	if err := os.WriteFile(listPath, []byte(imdbText), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// first open builds the index
+	store, err := imdb.OpenStore(listPath, indexPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if store.Len() != 5 {
+		t.Errorf("expected 5 movies in the store, got %d", store.Len())
+	}
+	if _, err := os.Stat(indexPath); err != nil {
+		t.Fatalf("expected the index file to be written: %s", err)
+	}
+
+	// the list is replaced with a different list with the same header, so the
+	// 5 movies must have come from the index
+	sameHeader := imdbText[:strings.Index(imdbText, "MOVI:")]
+	if err := os.WriteFile(listPath, []byte(sameHeader+"MOVI: Emma (1996)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err = imdb.OpenStore(listPath, indexPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if store.Len() != 5 {
+		t.Errorf("expected 5 movies to be loaded from the index, got %d", store.Len())
+	}
+
+	// a new CRC means the index must be rebuilt
+	newHeader := strings.Replace(sameHeader, "0x527C5E79", "0x12345678", 1)
+	if err := os.WriteFile(listPath, []byte(newHeader+"MOVI: Emma (1996)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err = imdb.OpenStore(listPath, indexPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if store.Len() != 1 || store.DatabaseCRC() != 0x12345678 {
+		t.Errorf("expected the index to be rebuilt with 1 movie, got %d", store.Len())
+	}
+
+	// a truncated CRC line must not stop the header being read
+	truncatedHeader := strings.Replace(sameHeader, "CRC: 0x527C5E79  File: literature.list  Date: Fri Dec 22 00:00:00 2017", "CRC: ", 1)
+	if err := os.WriteFile(listPath, []byte(truncatedHeader+"MOVI: Emma (1996)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err = imdb.OpenStore(listPath, indexPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if store.Len() != 1 || store.DatabaseCRC() != 0 {
+		t.Errorf("expected the index to be rebuilt with 1 movie and no CRC, got %d", store.Len())
+	}
+}
+
+func TestOpenStoreWithoutHeader(t *testing.T) {
+	dir := t.TempDir()
+	listPath := filepath.Join(dir, "literature.list")
+	indexPath := filepath.Join(dir, "literature.idx")
+
+	header := imdbText[strings.Index(imdbText, "LITERATURE LIST"):strings.Index(imdbText, "MOVI:")]
+	if err := os.WriteFile(listPath, []byte(header+"MOVI: Emma (1996)\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := imdb.OpenStore(listPath, indexPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if store.Len() != 1 {
+		t.Fatalf("expected 1 movie in the store, got %d", store.Len())
+	}
+
+	// without a CRC or date, the index can't be trusted, so must be rebuilt
+	list := header + "MOVI: Emma (1996)\n\n" + strings.Repeat("-", 79) + "\nMOVI: Clueless (1995)\n"
+	if err := os.WriteFile(listPath, []byte(list), 0644); err != nil {
+		t.Fatal(err)
+	}
+	store, err = imdb.OpenStore(listPath, indexPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if store.Len() != 2 {
+		t.Errorf("expected the index to be rebuilt with 2 movies, got %d", store.Len())
+	}
+}
@@ -0,0 +1,126 @@
+package movie
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Role is the part a contributor played in a book or article.
+type Role string
+
+// List of all the contributor roles.
+const (
+	RoleAuthor      Role = "author"
+	RoleEditor      Role = "editor"
+	RoleTranslator  Role = "translator"
+	RoleIllustrator Role = "illustrator"
+)
+
+// Contributor is a person named in the author part of an entry, along with
+// their role, e.g. `Grossman, Edith (trans.)` is a translator.
+type Contributor struct {
+	Name string
+	Role Role
+}
+
+var (
+	contributorSeparators = regexp.MustCompile(`\s+(?:and|&)\s+|\s*;\s*|\s+/\s+`)
+	contributorPrefix     = regexp.MustCompile(`(?i)^(edited|translated|illustrated) by\s+`)
+	contributorSuffix     = regexp.MustCompile(`(?i)(?:\s*\(\s*([a-z.]+)\s*\)|,\s*([a-z.]+))$`)
+)
+
+// roleWords maps the words marking a role, without any trailing full stop,
+// to the role. Plural words also apply to the unmarked contributors before them.
+var roleWords = map[string]Role{
+	"author":       RoleAuthor,
+	"ed":           RoleEditor,
+	"eds":          RoleEditor,
+	"edited":       RoleEditor,
+	"editor":       RoleEditor,
+	"editors":      RoleEditor,
+	"tr":           RoleTranslator,
+	"trans":        RoleTranslator,
+	"translated":   RoleTranslator,
+	"translator":   RoleTranslator,
+	"translators":  RoleTranslator,
+	"ill":          RoleIllustrator,
+	"illus":        RoleIllustrator,
+	"illustrated":  RoleIllustrator,
+	"illustrator":  RoleIllustrator,
+	"illustrators": RoleIllustrator,
+}
+
+func isPluralRole(word string) bool {
+	return word == "eds" || word == "editors" || word == "translators" || word == "illustrators"
+}
+
+// parseContributors splits the author part of an entry into its contributors,
+// e.g. `Smith, John and Doe, Jane (eds.)`. Names without a role marker are
+// authors, unless a plural marker follows them, which applies to all the
+// unmarked names before it.
+func parseContributors(text string) []Contributor {
+	var contributors []Contributor
+	if len(strings.TrimSpace(text)) == 0 {
+		return contributors
+	}
+
+	for _, part := range contributorSeparators.Split(text, -1) {
+		c := Contributor{Name: strings.TrimSpace(part)}
+
+		if m := contributorPrefix.FindStringSubmatch(c.Name); m != nil {
+			c.Role = roleWords[strings.ToLower(m[1])]
+			c.Name = c.Name[len(m[0]):]
+		}
+		if m := contributorSuffix.FindStringSubmatch(c.Name); m != nil {
+			word := strings.TrimSuffix(strings.ToLower(m[1]+m[2]), ".")
+			// after a comma, abbreviations must be lower case, as `McBain, Ed` is a
+			// name. The full stop can't be relied on, as extractAuthor strips the
+			// one ending the author part.
+			abbreviated := len(m[2]) > 0 && m[2] != strings.ToLower(m[2]) && len(word) <= len("illus")
+			if role, ok := roleWords[word]; ok && !abbreviated {
+				c.Role = role
+				c.Name = c.Name[:len(c.Name)-len(m[0])]
+				if isPluralRole(word) {
+					for i := range contributors {
+						if len(contributors[i].Role) == 0 {
+							contributors[i].Role = role
+						}
+					}
+				}
+			}
+		}
+
+		c.Name = strings.TrimSpace(strings.TrimRight(c.Name, ", "))
+		if len(c.Name) > 0 {
+			contributors = append(contributors, c)
+		}
+	}
+
+	for i := range contributors {
+		if len(contributors[i].Role) == 0 {
+			contributors[i].Role = RoleAuthor
+		}
+	}
+
+	return contributors
+}
+
+// AuthorNames returns the names of the contributors with the author role.
+// When there are no contributors, such as for a Book created by hand, the
+// Author is returned instead.
+func (b *Book) AuthorNames() []string {
+	if len(b.Contributors) == 0 {
+		if len(b.Author) == 0 {
+			return nil
+		}
+		return []string{b.Author}
+	}
+
+	var names []string
+	for _, c := range b.Contributors {
+		if c.Role == RoleAuthor {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,296 @@
+package movie
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Date is a generic type for storing dates without having to parse into time.Time objects.
+// Only the parts given in the entry are set, as described by the Precision.
+type Date struct {
+	Year   int
+	Month  int
+	Day    int
+	Season Season // only when given instead of a month, e.g. `Spring 1998`
+
+	// the end of a date range, such as `July/August 1975` or `Winter 1997/98`,
+	// where only the parts differing from the start are set.
+	EndYear   int
+	EndMonth  int
+	EndSeason Season
+
+	Precision DatePrecision
+	Circa     bool // an approximate date, e.g. `c. 1920`
+}
+
+// DatePrecision gives the most precise part of a Date.
+type DatePrecision int
+
+// List of all the date precisions.
+const (
+	PrecisionNone   DatePrecision = iota // no date was given
+	PrecisionYear                        // e.g. `1998`
+	PrecisionSeason                      // e.g. `Spring 1998`
+	PrecisionMonth                       // e.g. `May 1998`
+	PrecisionDay                         // e.g. `12 May 1998`
+)
+
+// Season of the year, as used by quarterly publications.
+type Season int
+
+// List of all the seasons. A season is ordered as starting on its first
+// month (January, April, July, and October), as publications use them for
+// quarterly issues.
+const (
+	NoSeason Season = iota
+	Winter
+	Spring
+	Summer
+	Autumn
+)
+
+var seasonNames = []string{"", "Winter", "Spring", "Summer", "Autumn"}
+
+func (s Season) String() string {
+	if s < NoSeason || s > Autumn {
+		return ""
+	}
+	return seasonNames[s]
+}
+
+// month returns the first month of the season.
+func (s Season) month() int {
+	if s == NoSeason {
+		return 0
+	}
+	return int(s-Winter)*3 + 1
+}
+
+var circaRegExp = regexp.MustCompile(`\A(?:c\.|ca\.|circa) *`)
+
+var dateRegExp = regexp.MustCompile(`\A(?:(\d{4})-(\d\d)(?:-(\d\d))?|(?:(\d{1,2}) +)?(?:([A-Z][a-z]+\.?)(?:[/-]([A-Z][a-z]+\.?))? +)?(\d{4})(?:/(\d{4}|\d\d))?)\z`)
+
+// ParseDate parses a date in any of the forms used by the entries:
+//
+//	1998, May 1998, 12 May 1998, Sept. 1998, Spring 1998, July/August 1975,
+//	Winter 1997/98, 1998-05-12, 1998-05, c. 1920
+//
+// Month and season names are in English, and a month name which is not
+// recognised is ignored, giving a date with only a year.
+func ParseDate(text string) (Date, error) {
+	var date Date
+
+	text = strings.TrimSpace(text)
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "("), ")")
+	if circa := circaRegExp.FindString(text); len(circa) > 0 {
+		date.Circa = true
+		text = text[len(circa):]
+	}
+
+	m := dateRegExp.FindStringSubmatch(text)
+	if m == nil {
+		return Date{}, fmt.Errorf("invalid date: %s", text)
+	}
+
+	// ISO dates: `1998-05-12`
+	if len(m[1]) > 0 {
+		date.Year, _ = strconv.Atoi(m[1])
+		date.Month, _ = strconv.Atoi(m[2])
+		date.Day, _ = strconv.Atoi(m[3])
+		if date.Month < 1 || date.Month > 12 || date.Day > 31 {
+			return Date{}, fmt.Errorf("invalid date: %s", text)
+		}
+		date.Precision = PrecisionMonth
+		if date.Day > 0 {
+			date.Precision = PrecisionDay
+		}
+		return date, nil
+	}
+
+	date.Year, _ = strconv.Atoi(m[7])
+	date.Precision = PrecisionYear
+
+	if len(m[8]) > 0 {
+		date.EndYear, _ = strconv.Atoi(m[8])
+		if len(m[8]) == 2 {
+			date.EndYear += date.Year / 100 * 100
+			if date.EndYear < date.Year {
+				date.EndYear += 100
+			}
+		}
+	}
+
+	if month := monthNumber(m[5]); month > 0 {
+		date.Month = month
+		date.EndMonth = monthNumber(m[6])
+		date.Precision = PrecisionMonth
+	} else if season := seasonNumber(m[5]); season != NoSeason {
+		date.Season = season
+		date.EndSeason = seasonNumber(m[6])
+		date.Precision = PrecisionSeason
+	}
+
+	if len(m[4]) > 0 && date.Month > 0 {
+		date.Day, _ = strconv.Atoi(m[4])
+		date.Precision = PrecisionDay
+	}
+
+	return date, nil
+}
+
+var months = []string{"january", "february", "march", "april", "may", "june", "july", "august", "september", "october", "november", "december"}
+
+// monthNumber returns the number of a month given by its English name, or its
+// abbreviation, e.g. `Sep`, `Sept.`, or 0 when not a month.
+func monthNumber(name string) int {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if name == "sept" {
+		return 9
+	}
+	for i, m := range months {
+		if name == m || len(name) == 3 && strings.HasPrefix(m, name) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func seasonNumber(name string) Season {
+	switch strings.ToLower(name) {
+	case "winter":
+		return Winter
+	case "spring":
+		return Spring
+	case "summer":
+		return Summer
+	case "autumn", "fall":
+		return Autumn
+	}
+	return NoSeason
+}
+
+// IsZero reports whether no date was given.
+func (d Date) IsZero() bool {
+	return d.Year == 0
+}
+
+// String returns the date in the style of the entries, e.g. `12 May 1998`,
+// `July/August 1975`, or `c. 1920`.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+
+	var parts []string
+	if d.Circa {
+		parts = append(parts, "c.")
+	}
+	if d.Day > 0 {
+		parts = append(parts, strconv.Itoa(d.Day))
+	}
+
+	switch {
+	case d.Month > 0 && d.EndMonth > 0:
+		parts = append(parts, monthName(d.Month)+"/"+monthName(d.EndMonth))
+	case d.Month > 0:
+		parts = append(parts, monthName(d.Month))
+	case d.Season != NoSeason && d.EndSeason != NoSeason:
+		parts = append(parts, d.Season.String()+"/"+d.EndSeason.String())
+	case d.Season != NoSeason:
+		parts = append(parts, d.Season.String())
+	}
+
+	year := strconv.Itoa(d.Year)
+	if d.EndYear > 0 && d.EndYear != d.Year {
+		year += "/" + strconv.Itoa(d.EndYear)
+	}
+	parts = append(parts, year)
+
+	return strings.Join(parts, " ")
+}
+
+func monthName(month int) string {
+	if month < 1 || month > 12 {
+		return ""
+	}
+	return time.Month(month).String()
+}
+
+// Before reports whether the start of the date is before the start of the
+// other date. Missing parts are treated as the earliest possible, so 1998 is
+// before May 1998, and seasons start on their first month.
+func (d Date) Before(other Date) bool {
+	return partsBefore(d.startParts(), other.startParts())
+}
+
+// endsBefore reports whether the end of the date is before the start of the
+// other date. Missing parts are treated as the latest possible, so May 1998
+// ends before June 1998, but 1998 does not, and `Winter 1997/98` ends with
+// the winter of 1998.
+func (d Date) endsBefore(other Date) bool {
+	return partsBefore(d.endParts(), other.startParts())
+}
+
+func partsBefore(a, b [3]int) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func (d Date) startParts() [3]int {
+	month := d.Month
+	if month == 0 {
+		month = d.Season.month()
+	}
+	return [3]int{d.Year, month, d.Day}
+}
+
+// endParts returns the last year, month, and day the date covers, using 12
+// and 31 for missing months and days, which is enough for comparing dates.
+func (d Date) endParts() [3]int {
+	year, month, day := d.Year, d.Month, d.Day
+	if month == 0 && d.Season != NoSeason {
+		month = d.Season.month() + 2
+	}
+	if d.EndYear > 0 {
+		year = d.EndYear
+		day = 0
+	}
+	if d.EndMonth > 0 {
+		month = d.EndMonth
+		day = 0
+	} else if d.EndSeason != NoSeason {
+		month = d.EndSeason.month() + 2
+	}
+	if month == 0 {
+		month = 12
+	}
+	if day == 0 {
+		day = 31
+	}
+	return [3]int{year, month, day}
+}
+
+// Time returns the start of the date, in UTC, with missing parts set to the
+// earliest possible, e.g. 1 January for a date with only a year. A date with
+// no year returns the zero time.
+func (d Date) Time() time.Time {
+	if d.IsZero() {
+		return time.Time{}
+	}
+	p := d.startParts()
+	month, day := p[1], p[2]
+	if month == 0 {
+		month = 1
+	}
+	if day == 0 {
+		day = 1
+	}
+	return time.Date(d.Year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
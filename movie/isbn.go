@@ -0,0 +1,201 @@
+package movie
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NormaliseISBN validates the check digit of an ISBN-10 or ISBN-13, returning
+// it in its ISBN-13 form, without hyphens.
+func NormaliseISBN(isbn string) (string, error) {
+	return isbn13(stripISBN(isbn))
+}
+
+// stripISBN removes any hyphens or spaces from an ISBN, and upper cases
+// the ISBN-10 `X` check digit.
+func stripISBN(isbn string) string {
+	isbn = strings.ReplaceAll(isbn, "-", "")
+	isbn = strings.ReplaceAll(isbn, " ", "")
+	return strings.ToUpper(isbn)
+}
+
+// isbn13 validates the check digit of a normalised ISBN, returning it in its
+// ISBN-13 form. ISBN-10 values are converted using the 978 prefix.
+func isbn13(isbn string) (string, error) {
+	switch len(isbn) {
+	case 10:
+		if !validISBN10(isbn) {
+			return "", fmt.Errorf("invalid ISBN-10 check digit: %s", isbn)
+		}
+		isbn = "978" + isbn[:9]
+		return isbn + isbn13CheckDigit(isbn), nil
+	case 13:
+		if !validISBN13(isbn) {
+			return "", fmt.Errorf("invalid ISBN-13 check digit: %s", isbn)
+		}
+		return isbn, nil
+	default:
+		return "", fmt.Errorf("invalid ISBN length: %s", isbn)
+	}
+}
+
+func validISBN10(isbn string) bool {
+	sum := 0
+	for i, c := range isbn {
+		var digit int
+		switch {
+		case c >= '0' && c <= '9':
+			digit = int(c - '0')
+		case c == 'X' && i == 9:
+			digit = 10
+		default:
+			return false
+		}
+		sum += digit * (10 - i)
+	}
+	return sum%11 == 0
+}
+
+func validISBN13(isbn string) bool {
+	if !strings.HasPrefix(isbn, "978") && !strings.HasPrefix(isbn, "979") {
+		return false
+	}
+	for _, c := range isbn {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return isbn13CheckDigit(isbn[:12]) == isbn[12:]
+}
+
+// isbn13CheckDigit calculates the check digit for the first 12 digits of an ISBN-13.
+func isbn13CheckDigit(isbn string) string {
+	sum := 0
+	for i, c := range isbn[:12] {
+		digit := int(c - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return fmt.Sprint((10 - sum%10) % 10)
+}
+
+// isbnMatches compares an entry ISBN against a normalised ISBN-13. When the
+// entry has an invalid check digit, only an exact match is possible.
+func isbnMatches(entryISBN ISBN, isbn string) bool {
+	if len(entryISBN) == 0 {
+		return false
+	}
+	if normalised, err := entryISBN.ISBN13(); err == nil {
+		return string(normalised) == isbn
+	}
+	return stripISBN(string(entryISBN)) == isbn
+}
+
+// ISBN is an International Standard Book Number, as given in an entry, which
+// may be an ISBN-10 or ISBN-13, with or without hyphens. Values with an
+// invalid length or check digit are kept as given, but are not Valid.
+type ISBN string
+
+// ParseISBN validates an ISBN-10 or ISBN-13, returning it without any
+// surrounding space. An ISSN is reported as such, rather than as an invalid ISBN.
+func ParseISBN(isbn string) (ISBN, error) {
+	i := ISBN(strings.TrimSpace(isbn))
+	if i.Valid() {
+		return i, nil
+	}
+	if ISSN(i).Valid() {
+		return "", fmt.Errorf("%s is an ISSN, not an ISBN", i)
+	}
+	_, err := isbn13(stripISBN(string(i)))
+	return "", err
+}
+
+// Valid reports whether the ISBN has a valid length and check digit.
+func (i ISBN) Valid() bool {
+	_, err := isbn13(stripISBN(string(i)))
+	return err == nil
+}
+
+// IsISBN10 reports whether the ISBN is a valid ISBN-10.
+func (i ISBN) IsISBN10() bool {
+	return i.Valid() && len(stripISBN(string(i))) == 10
+}
+
+// ISBN13 returns the ISBN in its ISBN-13 form, without hyphens. ISBN-10 values
+// are converted using the 978 prefix.
+func (i ISBN) ISBN13() (ISBN, error) {
+	isbn, err := isbn13(stripISBN(string(i)))
+	return ISBN(isbn), err
+}
+
+// Hyphenated returns the ISBN with its canonical hyphenation, in the same
+// ISBN-10 or ISBN-13 form as given, e.g. `978-0-8108-8122-8`. Invalid values
+// are returned as given.
+//
+// Only the registrant ranges of the English, French, German, and Japanese
+// language groups are known, and ISBNs in other groups are only hyphenated
+// after the prefix and before the check digit.
+func (i ISBN) Hyphenated() string {
+	isbn, err := i.ISBN13()
+	if err != nil {
+		return string(i)
+	}
+
+	s := string(isbn)
+	parts := []string{s[:3]}
+	parts = append(parts, isbnRegistrantParts(s[:3], s[3:12])...)
+	parts = append(parts, s[12:])
+
+	if i.IsISBN10() {
+		parts = parts[1:]
+		parts[len(parts)-1] = stripISBN(string(i))[9:]
+	}
+
+	return strings.Join(parts, "-")
+}
+
+// isbnRegistrantParts splits the 9 digits following the prefix into the
+// registration group, registrant, and publication element, using the known
+// ranges, or returns them unsplit when the group is not known.
+func isbnRegistrantParts(prefix, digits string) []string {
+	if prefix != "978" {
+		return []string{digits}
+	}
+	for _, g := range isbnGroups {
+		if !strings.HasPrefix(digits, g.group) {
+			continue
+		}
+		rest := digits[len(g.group):]
+		for _, r := range g.ranges {
+			if len(r.from) >= len(rest) {
+				continue
+			}
+			registrant := rest[:len(r.from)]
+			if registrant >= r.from && registrant <= r.to {
+				return []string{g.group, registrant, rest[len(r.from):]}
+			}
+		}
+	}
+	return []string{digits}
+}
+
+// isbnRange is a range of registrant elements, where the length of the
+// registrant is the length of the range values.
+type isbnRange struct {
+	from, to string
+}
+
+// isbnGroups holds the registrant ranges of the 978 registration groups
+// covering most of the entries in the DB.
+var isbnGroups = []struct {
+	group  string
+	ranges []isbnRange
+}{
+	{group: "0", ranges: []isbnRange{{"00", "19"}, {"200", "699"}, {"7000", "8499"}, {"85000", "89999"}, {"900000", "949999"}, {"9500000", "9999999"}}},
+	{group: "1", ranges: []isbnRange{{"00", "09"}, {"100", "399"}, {"4000", "5499"}, {"55000", "86979"}, {"869800", "998999"}, {"9990000", "9999999"}}},
+	{group: "2", ranges: []isbnRange{{"00", "19"}, {"200", "349"}, {"35000", "39999"}, {"400", "699"}, {"7000", "8399"}, {"84000", "89999"}, {"900000", "949999"}, {"9500000", "9999999"}}},
+	{group: "3", ranges: []isbnRange{{"00", "02"}, {"030", "033"}, {"0340", "0369"}, {"03700", "03999"}, {"04", "19"}, {"200", "699"}, {"7000", "8499"}, {"85000", "89999"}, {"900000", "949999"}, {"9500000", "9539999"}, {"95400", "96999"}, {"9700000", "9849999"}, {"98500", "99999"}}},
+	{group: "4", ranges: []isbnRange{{"00", "19"}, {"200", "699"}, {"7000", "8499"}, {"85000", "89999"}, {"900000", "949999"}, {"9500000", "9999999"}}},
+}
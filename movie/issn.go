@@ -0,0 +1,75 @@
+package movie
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ISSN is an International Standard Serial Number, as given in an entry, with
+// or without its hyphen. Values with an invalid length or check digit are
+// kept as given, but are not Valid.
+type ISSN string
+
+// ParseISSN validates an ISSN, returning it without any surrounding space.
+// An ISBN is reported as such, rather than as an invalid ISSN.
+func ParseISSN(issn string) (ISSN, error) {
+	i := ISSN(strings.TrimSpace(issn))
+	if i.Valid() {
+		return i, nil
+	}
+	if ISBN(i).Valid() {
+		return "", fmt.Errorf("%s is an ISBN, not an ISSN", i)
+	}
+	if len(stripISBN(string(i))) != 8 {
+		return "", fmt.Errorf("invalid ISSN length: %s", i)
+	}
+	return "", fmt.Errorf("invalid ISSN check digit: %s", i)
+}
+
+// Valid reports whether the ISSN has a valid length and check digit.
+func (i ISSN) Valid() bool {
+	issn := stripISBN(string(i))
+	if len(issn) != 8 {
+		return false
+	}
+
+	sum := 0
+	for n, c := range issn[:7] {
+		if c < '0' || c > '9' {
+			return false
+		}
+		sum += int(c-'0') * (8 - n)
+	}
+
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return issn[7] == 'X'
+	}
+	return int(issn[7]-'0') == check
+}
+
+// Hyphenated returns the ISSN in its canonical `NNNN-NNNC` form. Invalid values
+// are returned as given.
+func (i ISSN) Hyphenated() string {
+	if !i.Valid() {
+		return string(i)
+	}
+	issn := stripISBN(string(i))
+	return issn[:4] + "-" + issn[4:]
+}
+
+// standardNumbers places an identifier given as an ISBN or ISSN in the
+// correct field, going by its length, as the label is often wrong. Values
+// with an unknown length are placed according to their label.
+func standardNumbers(label, value string) (ISBN, ISSN) {
+	switch len(stripISBN(value)) {
+	case 8:
+		return "", ISSN(value)
+	case 10, 13:
+		return ISBN(value), ""
+	}
+	if label == "ISSN" {
+		return "", ISSN(value)
+	}
+	return ISBN(value), ""
+}
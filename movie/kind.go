@@ -0,0 +1,14 @@
+package movie
+
+// Kind is the kind of title a movie record is for, as given by the MOVI entry.
+type Kind string
+
+// List of all the kinds of title.
+const (
+	KindFeature   Kind = "feature"    // a film released in cinemas
+	KindTVMovie   Kind = "tv-movie"   // `(TV)`, made for TV
+	KindVideo     Kind = "video"      // `(V)`, released direct to video
+	KindVideoGame Kind = "video-game" // `(VG)`
+	KindTVSeries  Kind = "tv-series"  // a quoted title
+	KindTVEpisode Kind = "tv-episode" // a quoted title, followed by the `{episode}` details
+)
@@ -0,0 +1,149 @@
+package movie
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Matcher matches the book entries of a movie using optional, more lenient,
+// rules than the Movie match methods, which use the zero Matcher.
+type Matcher struct {
+	// PhoneticAuthors also matches author names that sound the same, such as
+	// the transliterations Tolstoy/Tolstoi and Chekhov/Tchekhov.
+	PhoneticAuthors bool
+}
+
+// MatchAdaptation checks all book types (ADPT, BOOK, NOVL) for a title/author
+// match, returning a report for the best matching entry. When more than one
+// entry has the same score, the first one found is reported.
+func (mt Matcher) MatchAdaptation(m *Movie, title, author string) (Match, bool) {
+	var best Match
+	found := false
+
+	for _, b := range m.BookEntries() {
+		match, ok := mt.matchBook(m, b, title, author)
+		if ok && (!found || match.Score > best.Score) {
+			best = match
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// MatchAuthor checks all book types (ADPT, BOOK, NOVL), returning a report
+// for every entry with an author match, regardless of its title.
+func (mt Matcher) MatchAuthor(m *Movie, author string) []Match {
+	var matches []Match
+
+	queryAuthor := NormaliseAuthor(author)
+	for _, b := range m.BookEntries() {
+		entryAuthor, rule, ok := mt.bookAuthorMatches(m, b, queryAuthor)
+		if !ok {
+			continue
+		}
+		matches = append(matches, Match{
+			Entry:       b.Entry,
+			Index:       b.Index,
+			Title:       NormaliseTitle(b.Title),
+			Author:      entryAuthor,
+			QueryAuthor: queryAuthor,
+			Rules:       []MatchRule{rule},
+		})
+	}
+
+	return matches
+}
+
+func (mt Matcher) matchBook(m *Movie, b BookEntry, title, author string) (Match, bool) {
+	match := Match{
+		Entry:       b.Entry,
+		Index:       b.Index,
+		Title:       NormaliseTitle(b.Title),
+		Author:      NormaliseAuthor(b.Author),
+		QueryTitle:  NormaliseTitle(title),
+		QueryAuthor: NormaliseAuthor(author),
+	}
+
+	if !m.titleMatches(match.Title, match.QueryTitle) {
+		return match, false
+	}
+	entryAuthor, authorRule, ok := mt.bookAuthorMatches(m, b, match.QueryAuthor)
+	if !ok {
+		return match, false
+	}
+	match.Author = entryAuthor
+
+	if match.Title == match.QueryTitle {
+		match.Rules = append(match.Rules, TitleExact)
+		match.Score = 1
+	} else {
+		match.Rules = append(match.Rules, TitleContains)
+		match.Score = float64(len(match.QueryTitle)) / float64(len(match.Title))
+	}
+	match.Rules = append(match.Rules, authorRule)
+
+	return match, true
+}
+
+// bookAuthorMatches checks each author-role contributor of the book against
+// the normalised query author, returning the normalised name that matched.
+func (mt Matcher) bookAuthorMatches(m *Movie, b BookEntry, queryAuthor string) (string, MatchRule, bool) {
+	names := b.AuthorNames()
+	if len(names) == 0 {
+		names = []string{""} // only an empty query author can match
+	}
+
+	for _, name := range names {
+		name = NormaliseAuthor(name)
+		if rule, ok := mt.authorMatches(m, name, queryAuthor); ok {
+			return name, rule, true
+		}
+	}
+
+	return "", "", false
+}
+
+// authorMatches expects both authors to have been normalised, returning the
+// rule by which they matched.
+func (mt Matcher) authorMatches(m *Movie, srcAuthor, testAuthor string) (MatchRule, bool) {
+	if m.authorMatches(srcAuthor, testAuthor) {
+		return AuthorNames, true
+	}
+	if !mt.PhoneticAuthors {
+		return "", false
+	}
+
+	// each query name must either be in the author, or sound like one of its names
+	codes := map[string]bool{}
+	for _, name := range PhoneticWords(srcAuthor) {
+		codes[name] = true
+	}
+	for _, name := range strings.Fields(testAuthor) {
+		if strings.Contains(srcAuthor, name) {
+			continue
+		}
+		code := Phonetic(name)
+		if len(code) == 0 || !codes[code] {
+			return "", false
+		}
+	}
+
+	return AuthorPhonetic, true
+}
+
+// PhoneticWords returns the phonetic code of each word in the text.
+func PhoneticWords(text string) []string {
+	var codes []string
+
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, w := range words {
+		if code := Phonetic(w); len(code) > 0 {
+			codes = append(codes, code)
+		}
+	}
+
+	return codes
+}
@@ -0,0 +1,31 @@
+package movie
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MediaType is the kind of media a book or publication entry was published
+// in, as given by its marker, e.g. `(MG)`.
+type MediaType string
+
+// List of all the media type markers.
+const (
+	MediaBook      MediaType = "BK"
+	MediaHardback  MediaType = "HB"
+	MediaMagazine  MediaType = "MG"
+	MediaNewspaper MediaType = "NP"
+	MediaNovel     MediaType = "NOVEL"
+)
+
+var mediaTypeRegExp = regexp.MustCompile(`(?i), *\((BK|HB|MG|NP|Novel)\)`)
+
+// extractMediaType returns the first media type marker in the text. This must
+// be called before cleanRandomText, which removes the markers.
+func (e textEntry) extractMediaType(data string) MediaType {
+	results := mediaTypeRegExp.FindStringSubmatch(data)
+	if len(results) < 2 {
+		return ""
+	}
+	return MediaType(strings.ToUpper(results[1]))
+}
@@ -2,7 +2,10 @@
 // unmarshalled.
 package movie
 
-import "strings"
+import (
+	"regexp"
+	"strings"
+)
 
 // Movie parses an IMDB movie record text blob, extracting all metadata about
 // a movie title.
@@ -10,12 +13,17 @@ import "strings"
 // entry types; ADPT, NOVL, CRIT, SCRP, etc.
 type Movie struct {
 	Title         string
-	Year          int
+	Year          int    // UnknownYear when given as `????`
+	Disambiguator string // IMDb's Roman numeral index for same-titled movies of the same year, e.g. "II" in `Hamlet (1990/II)`
 	Month         int
+	Kind          Kind
 	TV            bool
+	Series        bool // title is a TV series, which are quoted in the MOVI entry
+	Suspended     bool // IMDb has pulled the title, marked as `{{SUSPENDED}}` in the MOVI entry
 	SeriesName    string
 	SeriesNumber  int
 	EpisodeNumber int
+	AirDate       Date // of an episode, when given in place of the episode details, e.g. `{(2003-05-12)}`
 
 	Adaptations         []Adaptation
 	Books               []Book
@@ -36,13 +44,16 @@ type Adaptation struct {
 // Book parse a BOOK (monographic book) record entry.
 type Book struct {
 	Title          string
-	Author         string
+	Author         string        // as given in the entry, which may name more than one contributor
+	Contributors   []Contributor // parsed from the Author
 	Publisher      Publisher
 	Date           Date
 	PageCount      int
 	Volume         string
 	Issue          string
-	ISBN           string
+	ISBN           ISBN
+	ISSN           ISSN      // only when given in place of an ISBN
+	MediaType      MediaType // e.g. MediaBook, MediaHardback
 	FirstPublished int
 	Note           string
 	MiscInfo       string // from the "In:" info; usually just www links or other random text.
@@ -92,17 +103,36 @@ type Publication struct {
 	Date      Date
 	Volume    string
 	Issue     string
-	ISSN      string // sometime contains an ISBN
+	ISSN      ISSN
+	ISBN      ISBN      // only when given in place of an ISSN
+	MediaType MediaType // e.g. MediaMagazine, MediaNewspaper
 
 	// details related specifically to the IMDB entries.
 	ArticleAuthor string
+	Contributors  []Contributor // parsed from the ArticleAuthor
 	ArticleTitle  string
-	ArticlePages  string // e.g. `1-17`, `56`, `23, 24, 66`
+	ArticlePages  string // as given, e.g. `1-17`, `56`, `23, 24, 66`
+	Pages         Pages  // the spans parsed from the ArticlePages
 
-	// The interview subject, only used by IVIW.
+	// The interview subject, only used by IVIW, as given in the entry, e.g.
+	// `screenwriter Harry Essex` from `(interview with screenwriter Harry Essex)`.
 	ArticleInterviewee string
 }
 
+// Interviewees returns each interview subject, splitting the ArticleInterviewee
+// on commas and the words "and" or "&".
+func (p *Publication) Interviewees() []string {
+	var names []string
+	for _, name := range intervieweeSeparators.Split(p.ArticleInterviewee, -1) {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+var intervieweeSeparators = regexp.MustCompile(`,|\s+(?:and|&)\s+`)
+
 // Publisher metadata is used by all books and publications for the publisher details.
 type Publisher struct {
 	Name    string
@@ -111,12 +141,8 @@ type Publisher struct {
 	Country string
 }
 
-// Date is a generic type for storing dates without having to parse into time.Time objects.
-type Date struct {
-	Year  int
-	Month int
-	Day   int
-}
+// UnknownYear is the Movie.Year of titles with an unknown year, given as `????`.
+const UnknownYear = -1
 
 // Unmarshall processes all record entries types.
 func Unmarshall(data string, movie *Movie) {
@@ -135,6 +161,14 @@ func Unmarshall(data string, movie *Movie) {
 	entry.screenplays(movie)
 }
 
+// UnmarshallTitle processes only the MOVI entry, for when the movie details
+// are needed before deciding whether to process the rest of the record.
+func UnmarshallTitle(data string, movie *Movie) {
+	entry := extractEntryDataTypes(data)
+
+	entry.movieTitleDetails(movie)
+}
+
 // UnmarshallBooks processes only the record entries types that are types of books.
 func UnmarshallBooks(data string, movie *Movie) {
 	entry := extractEntryDataTypes(data)
@@ -148,48 +182,155 @@ func UnmarshallBooks(data string, movie *Movie) {
 // IsAdaptation checks all book types (ADPT, BOOK, NOVL) and returns true if a
 // title/author match is found.
 func (m *Movie) IsAdaptation(title, author string) bool {
-	for _, a := range m.Adaptations {
-		if m.titleMatches(a.Title, title) && m.authorMatches(a.Author, author) {
-			return true
-		}
+	_, ok := m.MatchAdaptation(title, author)
+	return ok
+}
+
+// MatchAdaptation checks all book types (ADPT, BOOK, NOVL) for a title/author
+// match, returning a report for the best matching entry. When more than one
+// entry has the same score, the first one found is reported.
+func (m *Movie) MatchAdaptation(title, author string) (Match, bool) {
+	return Matcher{}.MatchAdaptation(m, title, author)
+}
+
+// MatchAuthor checks all book types (ADPT, BOOK, NOVL), returning a report
+// for every entry with an author match, regardless of its title.
+func (m *Movie) MatchAuthor(author string) []Match {
+	return Matcher{}.MatchAuthor(m, author)
+}
+
+// MatchISBN checks the ISBN of all book types (ADPT, BOOK, NOVL), and of all
+// publication types, which sometimes have an ISBN in place of the ISSN,
+// returning a report for the first matching entry. ISBN-10 and ISBN-13 values
+// are treated as equal, but an invalid isbn will never match.
+func (m *Movie) MatchISBN(isbn string) (Match, bool) {
+	isbn, err := NormaliseISBN(isbn)
+	if err != nil {
+		return Match{}, false
 	}
-	for _, b := range m.Books {
-		if m.titleMatches(b.Title, title) && m.authorMatches(b.Author, author) {
-			return true
+
+	for _, b := range m.BookEntries() {
+		if isbnMatches(b.ISBN, isbn) {
+			return m.isbnMatch(b.Entry, b.Index, b.Title, b.Author), true
 		}
 	}
-	for _, n := range m.Novels {
-		if m.titleMatches(n.Title, title) && m.authorMatches(n.Author, author) {
-			return true
+	for _, p := range m.PublicationEntries() {
+		if isbnMatches(p.ISBN, isbn) {
+			return m.isbnMatch(p.Entry, p.Index, p.ArticleTitle, p.ArticleAuthor), true
 		}
 	}
 
-	return false
+	return Match{}, false
 }
 
-func (m *Movie) titleMatches(srcTitle, testTitle string) bool {
-	title := strings.ToLower(srcTitle)
-	testable := strings.ToLower(testTitle)
+func (m *Movie) isbnMatch(entry Key, index int, title, author string) Match {
+	return Match{
+		Entry:  entry,
+		Index:  index,
+		Title:  NormaliseTitle(title),
+		Author: NormaliseAuthor(author),
+		Rules:  []MatchRule{ISBNEqual},
+		Score:  1,
+	}
+}
 
-	title = strings.ReplaceAll(title, "the ", "")
-	testable = strings.ReplaceAll(testable, "the ", "")
+// BookEntry is a book along with the entry type and index it was parsed from.
+type BookEntry struct {
+	Entry Key // ADPT, BOOK, or NOVL
+	Index int
+	Book
+}
 
-	return strings.Contains(title, testable)
+// BookEntries returns all book types (ADPT, BOOK, NOVL), in that order.
+func (m *Movie) BookEntries() []BookEntry {
+	var entries []BookEntry
+	for i, a := range m.Adaptations {
+		entries = append(entries, BookEntry{Entry: ADPT, Index: i, Book: a.Book})
+	}
+	for i, b := range m.Books {
+		entries = append(entries, BookEntry{Entry: BOOK, Index: i, Book: b})
+	}
+	for i, n := range m.Novels {
+		entries = append(entries, BookEntry{Entry: NOVL, Index: i, Book: n.Book})
+	}
+	return entries
 }
 
-func (m *Movie) authorMatches(srcAuthor, testAuthor string) bool {
-	author := strings.ToLower(srcAuthor)
-	testable := strings.ToLower(testAuthor)
+// PublicationEntry is a publication along with the entry type and index it was parsed from.
+type PublicationEntry struct {
+	Entry Key // CRIT, ESSY, IVIW, OTHR, PROT, or SCRP
+	Index int
+	Publication
+}
+
+// PublicationEntries returns all publication types (CRIT, ESSY, IVIW, OTHR,
+// PROT, SCRP), in that order.
+func (m *Movie) PublicationEntries() []PublicationEntry {
+	var entries []PublicationEntry
+	for i, c := range m.Critiques {
+		entries = append(entries, PublicationEntry{Entry: CRIT, Index: i, Publication: c.Publication})
+	}
+	for i, e := range m.Essays {
+		entries = append(entries, PublicationEntry{Entry: ESSY, Index: i, Publication: e.Publication})
+	}
+	for i, v := range m.Interviews {
+		entries = append(entries, PublicationEntry{Entry: IVIW, Index: i, Publication: v.Publication})
+	}
+	for i, o := range m.Others {
+		entries = append(entries, PublicationEntry{Entry: OTHR, Index: i, Publication: o.Publication})
+	}
+	for i, p := range m.ProductionProtocols {
+		entries = append(entries, PublicationEntry{Entry: PROT, Index: i, Publication: p.Publication})
+	}
+	for i, s := range m.Screenplays {
+		entries = append(entries, PublicationEntry{Entry: SCRP, Index: i, Publication: s.Publication})
+	}
+	return entries
+}
+
+// Match reports which entry of a movie record satisfied a search, and why it matched.
+type Match struct {
+	Entry Key // e.g. ADPT, BOOK, or NOVL
+	Index int // position of the entry in its slice, e.g. Movie.Novels[Index]
 
-	author = strings.ReplaceAll(author, ",", "")
-	testable = strings.ReplaceAll(testable, ",", "")
+	// the normalised strings that were compared.
+	Title       string
+	Author      string
+	QueryTitle  string
+	QueryAuthor string
 
+	Alias     string // the alias of the query title that matched, if not the query title itself
+	Pseudonym string // the other name of the query author that matched, if not the query author itself
+
+	Rules []MatchRule // the rules that passed
+	Score float64     // 0-1, e.g. how much of the entry title is covered by the query title
+}
+
+// MatchRule names a rule used when matching a book entry.
+type MatchRule string
+
+// List of all the rules that can be reported in a Match.
+const (
+	TitleExact     MatchRule = "title-exact"     // titles are identical
+	TitleContains  MatchRule = "title-contains"  // entry title contains the query title
+	AuthorNames    MatchRule = "author-names"    // entry author contains each of the query names
+	AuthorPhonetic MatchRule = "author-phonetic" // entry author sounds like each of the query names
+	ISBNEqual      MatchRule = "isbn-equal"      // entry ISBN is the same as the query, in ISBN-10 or ISBN-13 form
+)
+
+// titleMatches expects both titles to have been normalised.
+func (m *Movie) titleMatches(srcTitle, testTitle string) bool {
+	return strings.Contains(srcTitle, testTitle)
+}
+
+// authorMatches expects both authors to have been normalised.
+func (m *Movie) authorMatches(srcAuthor, testAuthor string) bool {
 	// perhaps splitting the name on spaces and checking each part is present
 	// would be a reasonable approach:
 	matching := true
-	names := strings.Fields(testable)
+	names := strings.Fields(testAuthor)
 	for _, name := range names {
-		if !strings.Contains(author, name) {
+		if !strings.Contains(srcAuthor, name) {
 			matching = false
 		}
 	}
@@ -197,7 +338,19 @@ func (m *Movie) authorMatches(srcAuthor, testAuthor string) bool {
 	return matching
 }
 
-var monthRomanToInt = map[string]int{
-	"i": 1, "ii": 2, "iii": 3, "iv": 4, "v": 5, "vi": 6,
-	"vii": 7, "viii": 8, "ix": 9, "x": 10, "xi": 11, "xii": 12,
+// NormaliseTitle returns the title as compared by the matcher: lower case,
+// with the word "the" removed, but not where it ends a longer word, such as
+// in "Breathe".
+func NormaliseTitle(title string) string {
+	title = strings.ToLower(title)
+	return theRegExp.ReplaceAllString(title, "$1")
+}
+
+var theRegExp = regexp.MustCompile(`(^|[^\pL\pN])the `)
+
+// NormaliseAuthor returns the author as compared by the matcher: lower case,
+// with any commas removed.
+func NormaliseAuthor(author string) string {
+	author = strings.ToLower(author)
+	return strings.ReplaceAll(author, ",", "")
 }
@@ -7,18 +7,20 @@ import (
 )
 
 type BookTableData struct {
-	text, title, author, isbn, note string
-	pageCount, firstPublished       int
-	volume, issue                   string
-	publisher                       [4]string // name, city, country
-	date                            [3]int    // year, month, day
+	text, title, author, note string
+	isbn                      movie.ISBN
+	pageCount, firstPublished int
+	volume, issue             string
+	publisher                 [4]string // name, city, country
+	date                      [3]int    // year, month, day
 }
 
 type PublicationTableData struct {
-	text, author, title, pages       string
-	publication, volume, issue, issn string
-	publisher                        [4]string // name, city, country
-	date                             [3]int    // year, month, day
+	text, author, title, pages string
+	publication, volume, issue string
+	issn                       movie.ISSN
+	publisher                  [4]string // name, city, country
+	date                       [3]int    // year, month, day
 }
 
 func TestMovieTitleDetails(t *testing.T) {
@@ -33,12 +35,18 @@ func TestMovieTitleDetails(t *testing.T) {
 	if mov.Year != 1954 {
 		t.Fatalf("expected year as 1954, got %d", mov.Year)
 	}
-	if mov.Month != 11 {
-		t.Fatalf("expected month as 11, got %d", mov.Month)
+	if mov.Disambiguator != "XI" {
+		t.Fatalf("expected disambiguator as XI, got '%s'", mov.Disambiguator)
+	}
+	if mov.Month != 0 {
+		t.Fatalf("expected no month, got %d", mov.Month)
 	}
 	if !mov.TV {
 		t.Fatalf("expected TV to be true")
 	}
+	if mov.Series {
+		t.Fatalf("expected Series to be false")
+	}
 }
 
 func TestMultipleTitles(t *testing.T) {
@@ -106,6 +114,92 @@ func TestMovieSeriesName(t *testing.T) {
 	if mov.SeriesName != "King Lear" {
 		t.Fatalf("expected series to be King Lear, got %s", mov.SeriesName)
 	}
+	if !mov.Series {
+		t.Fatalf("expected the quoted title to be a series")
+	}
+}
+
+func TestMovieEpisodeAirDate(t *testing.T) {
+	testItems := []struct {
+		entry      string
+		seriesName string
+		date       [3]int
+	}{
+		{entry: `MOVI: "The Bill" (1984) {(2003-05-12)}`, date: [3]int{2003, 5, 12}},
+		{entry: `MOVI: "Jackanory" (1965) {The Hobbit (1979-10-01)}`, seriesName: "The Hobbit", date: [3]int{1979, 10, 1}},
+		{entry: `MOVI: "Jackanory" (1965) {The Hobbit}`, seriesName: "The Hobbit"},
+	}
+
+	for i, item := range testItems {
+		mov := movie.Movie{}
+		movie.Unmarshall(item.entry, &mov)
+
+		if mov.SeriesName != item.seriesName {
+			t.Errorf("(#%d) expected series name '%s', got '%s'", i, item.seriesName, mov.SeriesName)
+		}
+		if d := mov.AirDate; d.Year != item.date[0] || d.Month != item.date[1] || d.Day != item.date[2] {
+			t.Errorf("(#%d) unexpected air date, got %+v", i, d)
+		}
+	}
+}
+
+func TestMovieUnknownYear(t *testing.T) {
+	mov := movie.Movie{}
+
+	entry := `MOVI: Frankenstein (????/II)`
+	movie.Unmarshall(entry, &mov)
+
+	if mov.Title != "Frankenstein" {
+		t.Fatalf("expected title to be extracted, got: '%s'", mov.Title)
+	}
+	if mov.Year != movie.UnknownYear {
+		t.Fatalf("expected an unknown year, got %d", mov.Year)
+	}
+	if mov.Disambiguator != "II" {
+		t.Fatalf("expected disambiguator as II, got '%s'", mov.Disambiguator)
+	}
+}
+
+func TestMovieSuspended(t *testing.T) {
+	mov := movie.Movie{}
+
+	entry := `MOVI: "Bleak House" (1985) {Episode Two (#1.2)} {{SUSPENDED}}`
+	movie.Unmarshall(entry, &mov)
+
+	if !mov.Suspended {
+		t.Fatalf("expected the title to be suspended")
+	}
+	if mov.SeriesName != "Episode Two" || mov.SeriesNumber != 1 || mov.EpisodeNumber != 2 {
+		t.Fatalf("expected the episode details to be extracted, got '%s' (#%d.%d)", mov.SeriesName, mov.SeriesNumber, mov.EpisodeNumber)
+	}
+	if mov.Kind != movie.KindTVEpisode {
+		t.Fatalf("expected a TV episode, got '%s'", mov.Kind)
+	}
+}
+
+func TestMovieKind(t *testing.T) {
+	testItems := []struct {
+		entry string
+		kind  movie.Kind
+	}{
+		{entry: `MOVI: Hamlet (1990/II)`, kind: movie.KindFeature},
+		{entry: `MOVI: Mansfield Park (2007) (TV)`, kind: movie.KindTVMovie},
+		{entry: `MOVI: Hamlet (2000) (V)`, kind: movie.KindVideo},
+		{entry: `MOVI: Dracula (1994) (VG)`, kind: movie.KindVideoGame},
+		{entry: `MOVI: "A Taste of Shakespeare" (1995)`, kind: movie.KindTVSeries},
+		{entry: `MOVI: "A Taste of Shakespeare" (1995) {King Lear}`, kind: movie.KindTVEpisode},
+		{entry: `MOVI: "A Shared House" (2015) {(#1.4)}`, kind: movie.KindTVEpisode},
+		{entry: `MOVI: "Fawlty Towers" (1975) {{SUSPENDED}}`, kind: movie.KindTVSeries},
+	}
+
+	for i, item := range testItems {
+		mov := movie.Movie{}
+		movie.Unmarshall(item.entry, &mov)
+
+		if mov.Kind != item.kind {
+			t.Errorf("(#%d) expected kind '%s', got '%s'", i, item.kind, mov.Kind)
+		}
+	}
 }
 
 func TestMovieSeriesEpisodes(t *testing.T) {
@@ -563,3 +657,650 @@ func TestIsNotAdaptation(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchAdaptation(t *testing.T) {
+	mov := movie.Movie{}
+	movie.Unmarshall(`MOVI: Oliver! (1968)
+BOOK: Dickens, Charles. "The Adventures of Oliver Twist"
+NOVL: Dickens, Charles. "Oliver Twist"`, &mov)
+
+	match, ok := mov.MatchAdaptation("Oliver Twist", "Charles Dickens")
+	if !ok {
+		t.Fatalf("expected movie to be an adaptation")
+	}
+
+	if match.Entry != movie.NOVL || match.Index != 0 {
+		t.Errorf("expected the exact NOVL match to be reported, got %s #%d", match.Entry, match.Index)
+	}
+	if match.Title != "oliver twist" || match.QueryTitle != "oliver twist" {
+		t.Errorf("unexpected normalised titles, got '%s' and '%s'", match.Title, match.QueryTitle)
+	}
+	if match.Author != "dickens charles" || match.QueryAuthor != "charles dickens" {
+		t.Errorf("unexpected normalised authors, got '%s' and '%s'", match.Author, match.QueryAuthor)
+	}
+	if match.Score != 1 {
+		t.Errorf("expected a score of 1, got %f", match.Score)
+	}
+	if len(match.Rules) != 2 || match.Rules[0] != movie.TitleExact || match.Rules[1] != movie.AuthorNames {
+		t.Errorf("unexpected rules, got %v", match.Rules)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	testItems := []struct {
+		text      string
+		date      movie.Date
+		formatted string
+	}{
+		{text: "1998", date: movie.Date{Year: 1998, Precision: movie.PrecisionYear}, formatted: "1998"},
+		{text: "(1995)", date: movie.Date{Year: 1995, Precision: movie.PrecisionYear}, formatted: "1995"},
+		{text: "May 1998", date: movie.Date{Year: 1998, Month: 5, Precision: movie.PrecisionMonth}, formatted: "May 1998"},
+		{text: "12 May 1998", date: movie.Date{Year: 1998, Month: 5, Day: 12, Precision: movie.PrecisionDay}, formatted: "12 May 1998"},
+		{text: "Sept. 1998", date: movie.Date{Year: 1998, Month: 9, Precision: movie.PrecisionMonth}, formatted: "September 1998"},
+		{text: "1 Jan 2001", date: movie.Date{Year: 2001, Month: 1, Day: 1, Precision: movie.PrecisionDay}, formatted: "1 January 2001"},
+		{text: "Spring 1998", date: movie.Date{Year: 1998, Season: movie.Spring, Precision: movie.PrecisionSeason}, formatted: "Spring 1998"},
+		{text: "Fall 1975", date: movie.Date{Year: 1975, Season: movie.Autumn, Precision: movie.PrecisionSeason}, formatted: "Autumn 1975"},
+		{text: "July/August 1975", date: movie.Date{Year: 1975, Month: 7, EndMonth: 8, Precision: movie.PrecisionMonth}, formatted: "July/August 1975"},
+		{text: "Winter 1997/98", date: movie.Date{Year: 1997, EndYear: 1998, Season: movie.Winter, Precision: movie.PrecisionSeason}, formatted: "Winter 1997/1998"},
+		{text: "1998-05-12", date: movie.Date{Year: 1998, Month: 5, Day: 12, Precision: movie.PrecisionDay}, formatted: "12 May 1998"},
+		{text: "1998-05", date: movie.Date{Year: 1998, Month: 5, Precision: movie.PrecisionMonth}, formatted: "May 1998"},
+		{text: "c. 1920", date: movie.Date{Year: 1920, Precision: movie.PrecisionYear, Circa: true}, formatted: "c. 1920"},
+		{text: "Februar 1659", date: movie.Date{Year: 1659, Precision: movie.PrecisionYear}, formatted: "1659"},
+	}
+
+	for i, item := range testItems {
+		date, err := movie.ParseDate(item.text)
+		if err != nil {
+			t.Errorf("(#%d) unexpected error: %s", i, err)
+			continue
+		}
+		if date != item.date {
+			t.Errorf("(#%d) unexpected date, got %+v", i, date)
+		}
+		if date.String() != item.formatted {
+			t.Errorf("(#%d) unexpected string, got '%s'", i, date.String())
+		}
+	}
+
+	for i, invalid := range []string{"", "May", "1998-13-01", "the 1990s"} {
+		if _, err := movie.ParseDate(invalid); err == nil {
+			t.Errorf("(#%d) expected '%s' to be invalid", i, invalid)
+		}
+	}
+}
+
+func TestDateOrdering(t *testing.T) {
+	dates := []string{"1997", "1998", "Winter 1998", "February 1998", "Spring 1998", "12 May 1998"}
+
+	for i := 1; i < len(dates); i++ {
+		a, _ := movie.ParseDate(dates[i-1])
+		b, _ := movie.ParseDate(dates[i])
+		if b.Before(a) {
+			t.Errorf("expected '%s' to not be before '%s'", b, a)
+		}
+		if a.Time().After(b.Time()) {
+			t.Errorf("expected the time of '%s' to not be after '%s'", a, b)
+		}
+	}
+
+	d, _ := movie.ParseDate("Spring 1998")
+	if tm := d.Time(); tm.Year() != 1998 || tm.Month() != 4 || tm.Day() != 1 {
+		t.Errorf("unexpected time, got %s", tm)
+	}
+	if !(movie.Date{}).Time().IsZero() {
+		t.Errorf("expected a zero date to have a zero time")
+	}
+}
+
+func TestPublicationDates(t *testing.T) {
+	mov := movie.Movie{}
+	movie.Unmarshall(`MOVI: Barry Lyndon (1975)
+CRIT: Kael, Pauline. "Kubrick's Gilded Age". In: "Film Comment" (USA), Vol. 11, July/August 1975, Pg. 20
+CRIT: "Barry Lyndon". In: "Sight and Sound" (UK), Spring 1976, Pg. 112-113
+BOOK: Smith, John. "Kubrick". Penguin, c. 1920`, &mov)
+
+	if d := mov.Critiques[0].Date; d.String() != "July/August 1975" || mov.Critiques[0].Name != "Film Comment" {
+		t.Errorf("unexpected date, got '%s' in '%s'", d, mov.Critiques[0].Name)
+	}
+	if d := mov.Critiques[1].Date; d.Season != movie.Spring || d.Year != 1976 {
+		t.Errorf("unexpected date, got '%s'", d)
+	}
+	if d := mov.Books[0].Date; !d.Circa || d.Year != 1920 {
+		t.Errorf("unexpected date, got '%s'", d)
+	}
+}
+
+func TestParsePages(t *testing.T) {
+	testItems := []struct {
+		text      string
+		count     int
+		formatted string
+	}{
+		{text: "56", count: 1, formatted: "56"},
+		{text: "1-17", count: 17, formatted: "1-17"},
+		{text: "23, 24, 66", count: 3, formatted: "23, 24, 66"},
+		{text: "213 to 222, 224, 383", count: 12, formatted: "213-222, 224, 383"},
+		{text: "288-94", count: 7, formatted: "288-294"},
+		{text: "c1+c10", count: 2, formatted: "C1+C10"},
+		{text: "S12-14", count: 3, formatted: "S12-S14"},
+		{text: "56+", count: 1, formatted: "56+"},
+		{text: "N/A", count: 0, formatted: ""},
+	}
+
+	for i, item := range testItems {
+		pages := movie.ParsePages(item.text)
+		if pages.Count() != item.count {
+			t.Errorf("(#%d) expected %d pages, got %d", i, item.count, pages.Count())
+		}
+		if pages.String() != item.formatted {
+			t.Errorf("(#%d) unexpected string, got '%s'", i, pages.String())
+		}
+	}
+
+	pages := movie.ParsePages("S12+14")
+	if len(pages) != 2 || pages[0].Prefix != "S" || !pages[0].Continued || pages[1].From != 14 {
+		t.Errorf("unexpected spans, got %+v", pages)
+	}
+
+	mov := movie.Movie{}
+	movie.Unmarshall(`MOVI: Barry Lyndon (1975)
+CRIT: "Barry Lyndon". In: "Sight and Sound" (UK), Spring 1976, Pg. 112-113`, &mov)
+	if c := mov.Critiques[0]; c.ArticlePages != "112-113" || c.Pages.Count() != 2 {
+		t.Errorf("expected the raw and parsed pages, got '%s' and %+v", c.ArticlePages, c.Pages)
+	}
+}
+
+func TestMediaTypes(t *testing.T) {
+	mov := movie.Movie{}
+	movie.Unmarshall(`MOVI: Mansfield Park (2007) (TV)
+BOOK: Hickman, Roger. "Ben-Hur: A Film Score Guide". The Scarecrow Press, Inc., 2011, ISBN-13: 978-0-810-88100-4, (hb)
+NOVL: Gary King. "Blind Rage". Onyx Books (1995), (BK), (Novel)
+ADPT: Austen, Jane. "Mansfield Park"
+CRIT: Relizzo, Donald. In: "Demonique" (Los Angeles, California, USA), FantaCo Enterprises Inc., Vol. 4, 1983, Pg. 20, (MG)
+PROT: Glendinning, Lee. "Rebirth Of Austen". In: "The Independent" (UK), Independent News & Media Ltd, 16 February 2007, Pg. 3, (NP)`, &mov)
+
+	testItems := []struct {
+		got  movie.MediaType
+		want movie.MediaType
+	}{
+		{got: mov.Books[0].MediaType, want: movie.MediaHardback},
+		{got: mov.Novels[0].MediaType, want: movie.MediaBook},
+		{got: mov.Adaptations[0].MediaType, want: ""},
+		{got: mov.Critiques[0].MediaType, want: movie.MediaMagazine},
+		{got: mov.ProductionProtocols[0].MediaType, want: movie.MediaNewspaper},
+	}
+	for i, item := range testItems {
+		if item.got != item.want {
+			t.Errorf("(#%d) expected media type '%s', got '%s'", i, item.want, item.got)
+		}
+	}
+
+	if mov.Novels[0].Note != "" {
+		t.Errorf("expected the markers to be removed from the entry, got note '%s'", mov.Novels[0].Note)
+	}
+
+	entries := mov.MatchPublications(movie.PublicationQuery{MediaTypes: []movie.MediaType{movie.MediaNewspaper}})
+	if len(entries) != 1 || entries[0].Entry != movie.PROT {
+		t.Errorf("expected only the newspaper entry to match, got %d entries", len(entries))
+	}
+}
+
+func TestInterviewees(t *testing.T) {
+	testItems := []struct {
+		text         string
+		interviewee  string
+		interviewees []string
+		title        string
+	}{
+		{
+			text:        `IVIW: "Fangoria" by: Tom Weaver, "The Creator from the Black Lagoon" (interview with screenwriter Harry Essex). (USA), Iss. 68, 1987`,
+			interviewee: "screenwriter Harry Essex", interviewees: []string{"screenwriter Harry Essex"},
+		},
+		{
+			text:        `IVIW: Tom Weaver. "Anatomy of a Mermaid: Ginger Stanley". In: "Classic Images" (Muscatine IA), Iss. 463, January 2014, Pg. 6-15, 70-81, (MG), (interview with Julie Adams' stunt double)`,
+			interviewee: "Julie Adams' stunt double", interviewees: []string{"Julie Adams' stunt double"}, title: "Anatomy of a Mermaid: Ginger Stanley",
+		},
+		{
+			text:        `IVIW: Smith, Jo. "Two Masters". In: "Sight and Sound" (UK), May 1990, Pg. 4, (MG), (interview with Akira Kurosawa, Ingmar Bergman and Federico Fellini)`,
+			interviewee: "Akira Kurosawa, Ingmar Bergman and Federico Fellini", interviewees: []string{"Akira Kurosawa", "Ingmar Bergman", "Federico Fellini"}, title: "Two Masters",
+		},
+		{
+			text:        `IVIW: "Starlog" by: Tom Weaver, "Creature Feature" (interview with John Smith (director)). (USA), Iss. 180, July 1992`,
+			interviewee: "John Smith (director)", interviewees: []string{"John Smith (director)"},
+		},
+		{
+			text:        `IVIW: Weaver, Tom. "Lagoon Days". In: "Starlog" (USA), July 1992, Pg. 10, (MG), (interview with Jane Doe (actress) and Bob Roe)`,
+			interviewee: "Jane Doe (actress) and Bob Roe", interviewees: []string{"Jane Doe (actress)", "Bob Roe"}, title: "Lagoon Days",
+		},
+		{
+			text:  `IVIW: Weaver, Tom. "Science Fiction and Fantasy Film Flashbacks". (Jefferson NC), McFarland & Co., 1998, Pg. 288-94, (BK), ISBN-10: 0786405643`,
+			title: "Science Fiction and Fantasy Film Flashbacks",
+		},
+	}
+
+	for i, item := range testItems {
+		mov := movie.Movie{}
+		movie.Unmarshall(item.text, &mov)
+		v := mov.Interviews[0]
+
+		if v.ArticleInterviewee != item.interviewee {
+			t.Errorf("(#%d) unexpected interviewee, got '%s'", i, v.ArticleInterviewee)
+		}
+		if names := v.Interviewees(); len(names) != len(item.interviewees) {
+			t.Errorf("(#%d) expected %d interviewees, got %v", i, len(item.interviewees), names)
+		} else {
+			for j, name := range names {
+				if name != item.interviewees[j] {
+					t.Errorf("(#%d) unexpected interviewee, got '%s'", i, name)
+				}
+			}
+		}
+		if len(item.title) > 0 && v.ArticleTitle != item.title {
+			t.Errorf("(#%d) unexpected title, got '%s'", i, v.ArticleTitle)
+		}
+	}
+
+	mov := movie.Movie{}
+	movie.Unmarshall(testItems[2].text, &mov)
+	if entries := mov.MatchPublications(movie.PublicationQuery{Interviewee: "Bergman, Ingmar"}); len(entries) != 1 {
+		t.Errorf("expected the interview to be found by interviewee, got %d entries", len(entries))
+	}
+	if entries := mov.MatchPublications(movie.PublicationQuery{Interviewee: "Tarkovsky"}); len(entries) != 0 {
+		t.Errorf("expected no interviews to be found, got %d entries", len(entries))
+	}
+}
+
+func TestNormaliseISBN(t *testing.T) {
+	testItems := [][]string{
+		{"0553213296", "9780553213294"},
+		{"0-8108-8122-5", "9780810881228"},
+		{"978-0-7864-9418-7", "9780786494187"},
+		{"080442957x", "9780804429573"},
+	}
+
+	for i, item := range testItems {
+		isbn, err := movie.NormaliseISBN(item[0])
+		if err != nil {
+			t.Errorf("(#%d) unexpected error: %s", i, err)
+		}
+		if isbn != item[1] {
+			t.Errorf("(#%d) unexpected ISBN, got '%s'", i, isbn)
+		}
+	}
+
+	for i, invalid := range []string{"042512240X", "978-0-810-88100-4", "12345", ""} {
+		if _, err := movie.NormaliseISBN(invalid); err == nil {
+			t.Errorf("(#%d) expected '%s' to be invalid", i, invalid)
+		}
+	}
+}
+
+func TestISBN(t *testing.T) {
+	testItems := []struct {
+		isbn       movie.ISBN
+		valid      bool
+		isbn13     movie.ISBN
+		hyphenated string
+	}{
+		{isbn: "0810881225", valid: true, isbn13: "9780810881228", hyphenated: "0-8108-8122-5"},
+		{isbn: "978-0-810-88122-8", valid: true, isbn13: "9780810881228", hyphenated: "978-0-8108-8122-8"},
+		{isbn: "9780553213294", valid: true, isbn13: "9780553213294", hyphenated: "978-0-553-21329-4"},
+		{isbn: "042503464X", valid: true, isbn13: "9780425034644", hyphenated: "0-425-03464-X"},
+		{isbn: "9783161484100", valid: true, isbn13: "9783161484100", hyphenated: "978-3-16-148410-0"},
+		{isbn: "9791032305690", valid: true, isbn13: "9791032305690", hyphenated: "979-103230569-0"},
+		{isbn: "042512240X", hyphenated: "042512240X"},
+		{isbn: "0758-4202", hyphenated: "0758-4202"},
+	}
+
+	for i, item := range testItems {
+		if item.isbn.Valid() != item.valid {
+			t.Errorf("(#%d) expected valid to be %t", i, item.valid)
+		}
+		if isbn13, _ := item.isbn.ISBN13(); isbn13 != item.isbn13 {
+			t.Errorf("(#%d) unexpected ISBN-13, got '%s'", i, isbn13)
+		}
+		if h := item.isbn.Hyphenated(); h != item.hyphenated {
+			t.Errorf("(#%d) unexpected hyphenation, got '%s'", i, h)
+		}
+	}
+
+	if _, err := movie.ParseISBN("0758-4202"); err == nil || err.Error() != "0758-4202 is an ISSN, not an ISBN" {
+		t.Errorf("expected an ISSN to be reported, got %v", err)
+	}
+	if isbn, err := movie.ParseISBN(" 0810881225 "); err != nil || isbn != "0810881225" {
+		t.Errorf("expected a valid ISBN, got '%s' %v", isbn, err)
+	}
+}
+
+func TestISSN(t *testing.T) {
+	testItems := []struct {
+		issn       movie.ISSN
+		valid      bool
+		hyphenated string
+	}{
+		{issn: "0758-4202", valid: true, hyphenated: "0758-4202"},
+		{issn: "21094217", valid: true, hyphenated: "2109-4217"},
+		{issn: "0317-8471", valid: true, hyphenated: "0317-8471"},
+		{issn: "2049-3630", valid: true, hyphenated: "2049-3630"},
+		{issn: "0758-4203", hyphenated: "0758-4203"},
+		{issn: "0810881225", hyphenated: "0810881225"},
+	}
+
+	for i, item := range testItems {
+		if item.issn.Valid() != item.valid {
+			t.Errorf("(#%d) expected valid to be %t", i, item.valid)
+		}
+		if h := item.issn.Hyphenated(); h != item.hyphenated {
+			t.Errorf("(#%d) unexpected hyphenation, got '%s'", i, h)
+		}
+	}
+
+	if _, err := movie.ParseISSN("0810881225"); err == nil || err.Error() != "0810881225 is an ISBN, not an ISSN" {
+		t.Errorf("expected an ISBN to be reported, got %v", err)
+	}
+}
+
+func TestStandardNumberPlacement(t *testing.T) {
+	mov := movie.Movie{}
+	movie.Unmarshall(`MOVI: Creature from the Black Lagoon (1954)
+BOOK: Weaver, Tom. "Monster Magazine". 1992, ISBN: 0758-4202
+CRIT: Delmas, Jean. "Cinéma". In: "Jeune Cinéma" (Paris, France), March 1966, Pg. 1, (MG), ISSN: 0758-4202
+IVIW: Weaver, Tom. "Science Fiction and Fantasy Film Flashbacks". (Jefferson NC), McFarland & Co., 1998, Pg. 288-94, (BK), ISSN: 0786405643`, &mov)
+
+	if b := mov.Books[0]; b.ISBN != "" || b.ISSN != "0758-4202" {
+		t.Errorf("expected the BOOK ISSN to be placed in the ISSN, got '%s' and '%s'", b.ISBN, b.ISSN)
+	}
+	if c := mov.Critiques[0]; c.ISBN != "" || c.ISSN != "0758-4202" {
+		t.Errorf("expected the CRIT ISSN to be placed in the ISSN, got '%s' and '%s'", c.ISBN, c.ISSN)
+	}
+	if v := mov.Interviews[0]; v.ISBN != "0786405643" || v.ISSN != "" {
+		t.Errorf("expected the IVIW ISBN to be placed in the ISBN, got '%s' and '%s'", v.ISBN, v.ISSN)
+	}
+}
+
+func TestMatchISBN(t *testing.T) {
+	mov := movie.Movie{}
+	movie.Unmarshall(`MOVI: Creature from the Black Lagoon (1954)
+BOOK: Tom Weaver. "The Creature Chronicles: Exploring the Black Lagoon Trilogy". (Jefferson NC), McFarland & Co., 2014, (BK), ISBN-13: 978-0-7864-9418-7
+IVIW: Weaver, Tom. "Science Fiction and Fantasy Film Flashbacks". (Jefferson NC), McFarland & Co., 1998, Pg. 288-94, (BK), ISBN-10: 0786405643`, &mov)
+
+	match, ok := mov.MatchISBN("0786494182")
+	if !ok {
+		t.Fatalf("expected the ISBN-10 to match the BOOK ISBN-13")
+	}
+	if match.Entry != movie.BOOK || match.Index != 0 {
+		t.Errorf("unexpected matching entry, got %s #%d", match.Entry, match.Index)
+	}
+
+	match, ok = mov.MatchISBN("978-0-7864-0564-0")
+	if !ok {
+		t.Fatalf("expected the ISBN-13 to match the IVIW ISBN")
+	}
+	if match.Entry != movie.IVIW || match.Rules[0] != movie.ISBNEqual {
+		t.Errorf("unexpected match, got %s %v", match.Entry, match.Rules)
+	}
+
+	if _, ok := mov.MatchISBN("0786494181"); ok {
+		t.Errorf("expected an invalid ISBN to not match")
+	}
+}
+
+func TestMatchPublications(t *testing.T) {
+	mov := movie.Movie{}
+	movie.Unmarshall(`MOVI: Creature from the Black Lagoon (1954)
+CRIT: D.. "La mujer y el monstruo". In: "ABC" (Madrid), 8 June 1955, Pg. 60-61, (NP)
+CRIT: Walker, John. In: "Total Film" (UK), July 1999, Pg. 106, (MG)
+ESSY: "The Creature from the Black Lagoon". In: "The Economist" (UK), Vol. 8570, 8 March 2008, Pg. 89, (MG)
+PROT: Weaver, Tom. "Behind the Black Lagoon". In: "Fangoria" (USA), Iss. 120, 1993, Pg. 14-19, 58-59`, &mov)
+
+	testItems := []struct {
+		query   movie.PublicationQuery
+		entries []movie.Key
+	}{
+		{query: movie.PublicationQuery{Name: "total film"}, entries: []movie.Key{movie.CRIT}},
+		{query: movie.PublicationQuery{Author: "Tom Weaver"}, entries: []movie.Key{movie.PROT}},
+		{query: movie.PublicationQuery{Types: []movie.Key{movie.CRIT, movie.ESSY}}, entries: []movie.Key{movie.CRIT, movie.CRIT, movie.ESSY}},
+		{query: movie.PublicationQuery{From: movie.Date{Year: 1993}, To: movie.Date{Year: 1999, Month: 6}}, entries: []movie.Key{movie.PROT}},
+		{query: movie.PublicationQuery{From: movie.Date{Year: 1999, Month: 7}, Types: []movie.Key{movie.CRIT}}, entries: []movie.Key{movie.CRIT}},
+		{query: movie.PublicationQuery{Name: "Sight and Sound"}},
+	}
+
+	for i, item := range testItems {
+		entries := mov.MatchPublications(item.query)
+		if len(entries) != len(item.entries) {
+			t.Fatalf("(#%d) expected %d entries to be found, got %d", i, len(item.entries), len(entries))
+		}
+		for j, e := range entries {
+			if e.Entry != item.entries[j] {
+				t.Errorf("(#%d) unexpected entry type, got %s", i, e.Entry)
+			}
+		}
+	}
+}
+
+func TestMatchPublicationsDateRanges(t *testing.T) {
+	mov := movie.Movie{}
+	movie.Unmarshall(`MOVI: Creature from the Black Lagoon (1954)
+ESSY: "Gill-Man". In: "Filmfax" (USA), Winter 1997/98, Pg. 40-45, (MG)
+OTHR: "Lagoon Days". In: "Scarlet Street" (USA), Spring 1998, Pg. 12, (MG)`, &mov)
+
+	testItems := []struct {
+		query   movie.PublicationQuery
+		entries []movie.Key
+	}{
+		{query: movie.PublicationQuery{From: movie.Date{Year: 1998}}, entries: []movie.Key{movie.ESSY, movie.OTHR}},
+		{query: movie.PublicationQuery{From: movie.Date{Year: 1998, Month: 4}}, entries: []movie.Key{movie.OTHR}},
+		{query: movie.PublicationQuery{From: movie.Date{Year: 1998, Month: 7}}},
+		{query: movie.PublicationQuery{To: movie.Date{Year: 1997}}, entries: []movie.Key{movie.ESSY}},
+		{query: movie.PublicationQuery{To: movie.Date{Year: 1998, Season: movie.Winter}}, entries: []movie.Key{movie.ESSY}},
+		{query: movie.PublicationQuery{From: movie.Date{Year: 1998, Month: 6}, To: movie.Date{Year: 1998, Month: 6}}, entries: []movie.Key{movie.OTHR}},
+		{query: movie.PublicationQuery{To: movie.Date{Year: 1996}}},
+	}
+
+	for i, item := range testItems {
+		entries := mov.MatchPublications(item.query)
+		if len(entries) != len(item.entries) {
+			t.Fatalf("(#%d) expected %d entries to be found, got %d", i, len(item.entries), len(entries))
+		}
+		for j, e := range entries {
+			if e.Entry != item.entries[j] {
+				t.Errorf("(#%d) unexpected entry type, got %s", i, e.Entry)
+			}
+		}
+	}
+}
+
+func TestNormalise(t *testing.T) {
+	testItems := []struct {
+		text   string
+		title  string
+		author string
+	}{
+		{text: "The Last of the Mohicans", title: "last of mohicans", author: "the last of the mohicans"},
+		{text: "Last of the Mohicans, The", title: "last of mohicans, the", author: "last of the mohicans the"},
+		{text: "Breathe Deep", title: "breathe deep", author: "breathe deep"},
+		{text: "Smith, Blythe", title: "smith, blythe", author: "smith blythe"},
+	}
+
+	for i, item := range testItems {
+		if title := movie.NormaliseTitle(item.text); title != item.title {
+			t.Errorf("(#%d) unexpected title, got '%s'", i, title)
+		}
+		if author := movie.NormaliseAuthor(item.text); author != item.author {
+			t.Errorf("(#%d) unexpected author, got '%s'", i, author)
+		}
+	}
+
+	mov := movie.Movie{}
+	movie.Unmarshall(`MOVI: Breathe Deep (2001)
+NOVL: Smith, Blythe. "Breathe Deep"`, &mov)
+	if !mov.IsAdaptation("Breathe", "Blythe Smith") {
+		t.Errorf("expected a word ending in 'the' to match")
+	}
+}
+
+func TestPhonetic(t *testing.T) {
+	testItems := [][]string{
+		{"Tolstoy", "Tolstoi"},
+		{"Dostoevsky", "Dostoyevsky", "Dostoievski"},
+		{"Chekhov", "Tchekhov"},
+		{"Tchaikovsky", "Tschaikowsky"},
+		{"Müller", "Muller", "Mueller"},
+	}
+
+	for i, names := range testItems {
+		code := movie.Phonetic(names[0])
+		if len(code) == 0 {
+			t.Fatalf("(#%d) expected a phonetic code for '%s'", i, names[0])
+		}
+		for _, name := range names[1:] {
+			if c := movie.Phonetic(name); c != code {
+				t.Errorf("(#%d) expected '%s' to sound like '%s', got %s and %s", i, name, names[0], c, code)
+			}
+		}
+	}
+
+	if movie.Phonetic("Tolstoy") == movie.Phonetic("Turgenev") {
+		t.Errorf("expected different authors to have different codes")
+	}
+}
+
+func TestMatcherPhoneticAuthors(t *testing.T) {
+	mov := movie.Movie{}
+	movie.Unmarshall(`NOVL: Tolstoi, Lev. "Anna Karenina"`, &mov)
+
+	if mov.IsAdaptation("Anna Karenina", "Tolstoy") {
+		t.Fatalf("expected no match without phonetic authors")
+	}
+
+	match, ok := movie.Matcher{PhoneticAuthors: true}.MatchAdaptation(&mov, "Anna Karenina", "Lev Tolstoy")
+	if !ok {
+		t.Fatalf("expected a phonetic author match")
+	}
+	if len(match.Rules) != 2 || match.Rules[1] != movie.AuthorPhonetic {
+		t.Errorf("unexpected rules, got %v", match.Rules)
+	}
+
+	match, _ = movie.Matcher{PhoneticAuthors: true}.MatchAdaptation(&mov, "Anna Karenina", "Tolstoi")
+	if match.Rules[1] != movie.AuthorNames {
+		t.Errorf("expected an exact author match to be preferred, got %v", match.Rules)
+	}
+}
+
+func TestContributors(t *testing.T) {
+	testItems := []struct {
+		text         string
+		contributors []movie.Contributor
+	}{
+		{
+			text:         `NOVL: Austen, Jane. "Emma"`,
+			contributors: []movie.Contributor{{Name: "Austen, Jane", Role: movie.RoleAuthor}},
+		},
+		{
+			text: `NOVL: Smith, John and Doe, Jane. "Two Hands"`,
+			contributors: []movie.Contributor{
+				{Name: "Smith, John", Role: movie.RoleAuthor},
+				{Name: "Doe, Jane", Role: movie.RoleAuthor},
+			},
+		},
+		{
+			text: `NOVL: Cervantes, Miguel de & Grossman, Edith (trans.). "Don Quixote"`,
+			contributors: []movie.Contributor{
+				{Name: "Cervantes, Miguel de", Role: movie.RoleAuthor},
+				{Name: "Grossman, Edith", Role: movie.RoleTranslator},
+			},
+		},
+		{
+			text:         `BOOK: Cunningham, Douglas A., editor. "The San Francisco of Alfred Hitchcock's Vertigo". Lanham, MD: The Scarecrow Press, 2011`,
+			contributors: []movie.Contributor{{Name: "Cunningham, Douglas A.", Role: movie.RoleEditor}},
+		},
+		{
+			text: `BOOK: Smith, John; Doe, Jane (eds.). "Essays"`,
+			contributors: []movie.Contributor{
+				{Name: "Smith, John", Role: movie.RoleEditor},
+				{Name: "Doe, Jane", Role: movie.RoleEditor},
+			},
+		},
+		{
+			text: `ADPT: Carroll, Lewis and illustrated by Tenniel, John. "Alice's Adventures in Wonderland"`,
+			contributors: []movie.Contributor{
+				{Name: "Carroll, Lewis", Role: movie.RoleAuthor},
+				{Name: "Tenniel, John", Role: movie.RoleIllustrator},
+			},
+		},
+		{
+			text: `BOOK: Smith, John and Doe, Jane (eds.) and Brown, Bob. "Essays"`,
+			contributors: []movie.Contributor{
+				{Name: "Smith, John", Role: movie.RoleEditor},
+				{Name: "Doe, Jane", Role: movie.RoleEditor},
+				{Name: "Brown, Bob", Role: movie.RoleAuthor},
+			},
+		},
+		{
+			text:         `BOOK: Simon, Paul, ed. "Songs"`,
+			contributors: []movie.Contributor{{Name: "Simon, Paul", Role: movie.RoleEditor}},
+		},
+		{
+			text: `NOVL: Tolstoy, Leo and Maude, Louise, trans. "War and Peace"`,
+			contributors: []movie.Contributor{
+				{Name: "Tolstoy, Leo", Role: movie.RoleAuthor},
+				{Name: "Maude, Louise", Role: movie.RoleTranslator},
+			},
+		},
+		{
+			text: `NOVL: Verne, Jules and Baldick, Robert, tr. and Riou, Edouard, ill. "Journey"`,
+			contributors: []movie.Contributor{
+				{Name: "Verne, Jules", Role: movie.RoleAuthor},
+				{Name: "Baldick, Robert", Role: movie.RoleTranslator},
+				{Name: "Riou, Edouard", Role: movie.RoleIllustrator},
+			},
+		},
+		{
+			text:         `ADPT: McBain, Ed. "Fuzz"`,
+			contributors: []movie.Contributor{{Name: "McBain, Ed", Role: movie.RoleAuthor}},
+		},
+	}
+
+	for i, item := range testItems {
+		mov := movie.Movie{}
+		movie.Unmarshall(item.text, &mov)
+
+		books := mov.BookEntries()
+		if len(books) != 1 {
+			t.Fatalf("(#%d) expected 1 book entry, got %d", i, len(books))
+		}
+		got := books[0].Contributors
+		if len(got) != len(item.contributors) {
+			t.Errorf("(#%d) expected %d contributors, got %+v", i, len(item.contributors), got)
+			continue
+		}
+		for j, c := range got {
+			if c != item.contributors[j] {
+				t.Errorf("(#%d) unexpected contributor, got %+v", i, c)
+			}
+		}
+	}
+}
+
+func TestAdaptationContributors(t *testing.T) {
+	mov := movie.Movie{}
+	movie.Unmarshall(`MOVI: Don Quixote (2000) (TV)
+NOVL: Cervantes, Miguel de and Grossman, Edith (trans.). "Don Quixote"`, &mov)
+
+	match, ok := mov.MatchAdaptation("Don Quixote", "Miguel de Cervantes")
+	if !ok {
+		t.Fatalf("expected the author to match")
+	}
+	if match.Author != "cervantes miguel de" {
+		t.Errorf("expected the matching contributor to be reported, got '%s'", match.Author)
+	}
+	if mov.IsAdaptation("Don Quixote", "Edith Grossman") {
+		t.Errorf("expected the translator to not match")
+	}
+	if mov.IsAdaptation("Don Quixote", "Edith Cervantes") {
+		t.Errorf("expected names from different contributors to not match")
+	}
+	if len(mov.MatchAuthor("Grossman")) != 0 {
+		t.Errorf("expected the translator to not match an author search")
+	}
+}
@@ -0,0 +1,81 @@
+package movie
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Pages is a list of page spans, such as the pages of an article.
+type Pages []PageSpan
+
+// PageSpan is a single page, or a range of pages.
+type PageSpan struct {
+	Prefix    string // section prefix, e.g. `S` in `S12`, always upper case
+	From      int
+	To        int  // the same as From for a single page
+	Continued bool // continued on the next span, or on later pages when the last, e.g. `c1+c10`, `56+`
+}
+
+var pageSpanRegExp = regexp.MustCompile(`(?i)([a-z]?)(\d+)(?:\s*(?:-|to)\s*[a-z]?(\d+))?\s*(\+)?`)
+
+// ParsePages parses page spans from text such as `1-17`, `56`, `23, 24, 66`,
+// `213 to 222`, `c1+c10`, or `56+`. An abbreviated end page, as in `288-94`,
+// takes its missing digits from the start page. Text that is not a page
+// number is ignored.
+func ParsePages(text string) Pages {
+	var pages Pages
+
+	for _, m := range pageSpanRegExp.FindAllStringSubmatch(text, -1) {
+		span := PageSpan{Prefix: strings.ToUpper(m[1]), Continued: len(m[4]) > 0}
+		span.From, _ = strconv.Atoi(m[2])
+		span.To = span.From
+
+		if len(m[3]) > 0 {
+			span.To, _ = strconv.Atoi(m[3])
+			if span.To < span.From && len(m[3]) < len(m[2]) {
+				span.To, _ = strconv.Atoi(m[2][:len(m[2])-len(m[3])] + m[3])
+			}
+			if span.To < span.From {
+				span.To = span.From
+			}
+		}
+
+		pages = append(pages, span)
+	}
+
+	return pages
+}
+
+// Count returns the total number of pages in all the spans.
+func (p Pages) Count() int {
+	n := 0
+	for _, span := range p {
+		n += span.To - span.From + 1
+	}
+	return n
+}
+
+// String returns the spans in a canonical form, e.g. `1-17, 24, C1+C10`.
+func (p Pages) String() string {
+	var b strings.Builder
+	for i, span := range p {
+		if i > 0 && !p[i-1].Continued {
+			b.WriteString(", ")
+		}
+		b.WriteString(span.String())
+		if span.Continued {
+			b.WriteString("+")
+		}
+	}
+	return b.String()
+}
+
+// String returns the span as `12`, or `12-14`, with any prefix on both pages.
+func (s PageSpan) String() string {
+	from := s.Prefix + strconv.Itoa(s.From)
+	if s.To == s.From {
+		return from
+	}
+	return from + "-" + s.Prefix + strconv.Itoa(s.To)
+}
@@ -0,0 +1,202 @@
+package movie
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Phonetic returns a phonetic code for a single word, such that words that
+// sound alike, for example transliterated names such as Tolstoy/Tolstoi,
+// Dostoevsky/Dostoyevsky, and Chekhov/Tchekhov, have the same code.
+//
+// The encoding is a simplified version of the Double Metaphone primary code,
+// keeping the rules most useful for author names. Accents are ignored, and
+// words with no letters return an empty code.
+func Phonetic(word string) string {
+	w := []rune(phoneticLetters(word))
+	if len(w) == 0 {
+		return ""
+	}
+
+	at := func(i int) rune {
+		if i < 0 || i >= len(w) {
+			return 0
+		}
+		return w[i]
+	}
+	isVowel := func(i int) bool {
+		return strings.ContainsRune("AEIOUY", at(i))
+	}
+	next := func(i int, s string) bool {
+		return strings.HasPrefix(string(w[i:]), s)
+	}
+
+	var code strings.Builder
+	last := rune(0)
+	emit := func(s string) {
+		for _, c := range s {
+			if c != last {
+				code.WriteRune(c)
+			}
+			last = c
+		}
+	}
+
+	i := 0
+
+	// silent first letters
+	if next(0, "GN") || next(0, "KN") || next(0, "PN") || next(0, "WR") || next(0, "PS") {
+		i++
+	}
+
+	for ; i < len(w); i++ {
+		c := w[i]
+
+		// ignore double letters, except for CC, which may be `KS`
+		if c == at(i-1) && c != 'C' {
+			continue
+		}
+
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if i == 0 {
+				emit("A")
+			}
+			last = 0 // a vowel separates repeated consonant sounds
+		case 'B':
+			if !(i == len(w)-1 && at(i-1) == 'M') {
+				emit("P")
+			}
+		case 'C':
+			switch {
+			case next(i, "CH"):
+				emit("X")
+				i++
+			case next(i, "CIA"):
+				emit("X")
+			case next(i, "CI"), next(i, "CE"), next(i, "CY"), next(i, "CZ"):
+				emit("S")
+			case next(i, "CK"), next(i, "CQ"):
+				emit("K")
+				i++
+			default:
+				emit("K")
+			}
+		case 'D':
+			if next(i, "DG") && strings.ContainsRune("EIY", at(i+2)) {
+				emit("J")
+				i++
+			} else {
+				emit("T")
+			}
+		case 'G':
+			switch {
+			case next(i, "GH") && i == 0:
+				emit("K")
+				i++
+			case next(i, "GH"):
+				i++ // silent, as in Vaughan
+			case next(i, "GN"):
+				// silent, as in Gnome, the N is handled next
+			case strings.ContainsRune("EIY", at(i+1)):
+				emit("J")
+			default:
+				emit("K")
+			}
+		case 'H':
+			// only sounded before a vowel, when not after a consonant
+			if isVowel(i+1) && (i == 0 || isVowel(i-1)) {
+				emit("H")
+			}
+		case 'K':
+			if at(i-1) != 'C' {
+				emit("K")
+			}
+		case 'P':
+			if next(i, "PH") {
+				emit("F")
+				i++
+			} else {
+				emit("P")
+			}
+		case 'Q':
+			emit("K")
+		case 'S':
+			switch {
+			case next(i, "SCH"):
+				emit("X")
+				i += 2
+			case next(i, "SH"):
+				emit("X")
+				i++
+			case next(i, "SIO"), next(i, "SIA"):
+				emit("X")
+			case next(i, "SZ"):
+				emit("S")
+				i++
+			default:
+				emit("S")
+			}
+		case 'T':
+			switch {
+			case next(i, "TCH"), next(i, "TSCH"):
+				// silent, as in Tchekhov and Tschaikowsky
+			case next(i, "TH"):
+				emit("0")
+				i++
+			case next(i, "TIA"), next(i, "TIO"):
+				emit("X")
+			default:
+				emit("T")
+			}
+		case 'V':
+			emit("F")
+		case 'W':
+			// sounded before a vowel, and as the Slavic `V` in -owsky/-ewski
+			if isVowel(i + 1) {
+				emit("W")
+			} else if next(i, "WSK") {
+				emit("F")
+			}
+		case 'X':
+			if i == 0 {
+				emit("S")
+			} else {
+				emit("KS")
+			}
+		case 'Z':
+			emit("S")
+		default: // F, J, L, M, N, R
+			emit(string(c))
+		}
+	}
+
+	return code.String()
+}
+
+// phoneticLetters returns the upper cased letters of the word, with any
+// accents removed.
+func phoneticLetters(word string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(foldAccents(word)) {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// foldAccents removes any accents from the text, e.g. `Émile` is `Emile`.
+func foldAccents(text string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, text)
+	if err != nil {
+		return text
+	}
+	return folded
+}
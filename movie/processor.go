@@ -9,10 +9,11 @@ import (
 // compile all regular expression up front for major performance improvements
 var (
 	titleDetailsRegExp     = regexp.MustCompile(`\A(.*?) \(([0-9?]{4})(?:/([IVX]+))?\)`)
-	seriesEpisodeInBraces  = regexp.MustCompile(`{\(#(\d+)\.(\d+)\)}\z`)         // {(#1.6)}
-	seriesInfo             = regexp.MustCompile(`{(.+?)? +\(#(\d+)\.(\d+)\)}\z`) // {Venjança (#1.6)}
-	seriesNameOnly         = regexp.MustCompile(`{(.+?)}\z`)                     // {A Clockwork Orange im Ballhof}
-	authorRegExp           = regexp.MustCompile(`^(.+?)\. +"`)                   // NOTE: also matches the opening " of the title
+	seriesEpisodeInBraces  = regexp.MustCompile(`{\(#(\d+)\.(\d+)\)}\z`)                     // {(#1.6)}
+	seriesInfo             = regexp.MustCompile(`{(.+?)? +\(#(\d+)\.(\d+)\)}\z`)             // {Venjança (#1.6)}
+	seriesNameOnly         = regexp.MustCompile(`{(.+?)}\z`)                                 // {A Clockwork Orange im Ballhof}
+	seriesAirDate          = regexp.MustCompile(`{(?:(.+?) +)?\((\d{4})-(\d\d)-(\d\d)\)}\z`) // {(2003-05-12)}
+	authorRegExp           = regexp.MustCompile(`^(.+?)\. +"`)                               // NOTE: also matches the opening " of the title
 	titleRegExp            = regexp.MustCompile(`^"([^"]+?)"\.? *`)
 	publisherRegExp        = regexp.MustCompile(`^\(([^)]+?)\)(?:, *)?|^([^:]+?): *`)
 	publisherNameRegEx     = regexp.MustCompile(`^([^,]+)(?:, *)?`)
@@ -20,13 +21,14 @@ var (
 	bracesRegExp           = regexp.MustCompile(`^\((.+?)\)$`)
 	randomTextRegExp       = regexp.MustCompile(`(?i), *(?:\(BK\)|\(HB\)|\(MG\)|\(NP\)|\(Novel\)|NONE|Pg\. N/?A|\(tme\d+\))`)
 	inRegExp               = regexp.MustCompile(`In: "([^"]+?)"(?:, *)?`)
-	isbnRegExp             = regexp.MustCompile(`, *IS[BS]N(?:-\d\d)?: ([0-9X-]+)`)
+	isbnRegExp             = regexp.MustCompile(`, *(IS[BS]N)(?:-\d\d)?: ([0-9X-]+)`)
 	pageCountRegExp        = regexp.MustCompile(`, *Pg. *([0-9]+)`)
 	pageRangeCleanupRegExp = regexp.MustCompile(`(?i), *Pg\. *(?:pg[ds]?[.;?]|pg>\.|p/ n°\.|p[a^]gs\.|Pages: *) *`)
 	pageRangeRegExp        = regexp.MustCompile(`(?i), *Pg\. *((?:[a-z]?[0-9]+)(?:(?:-|\+|, *| *to *)[a-z]?[0-9]+)*)`)
 	firstPublishedRegExp   = regexp.MustCompile(`(?i)First published.+?(\d\d\d\d).?`)
-	publishedRegExp        = regexp.MustCompile(`\(?((?:\d{1,2} +)?(?:[JFMASOND][a-z]+ +)?\d{4})\)?`)
+	publishedRegExp        = regexp.MustCompile(`\(?((?:\b(?:c\.|ca\.|circa) *)?(?:\d{4}-(?:0[1-9]|1[0-2])(?:-\d\d)?\b|(?:\d{1,2} +)?(?:(?:[JFMASOND][a-z]+\.?|Winter)(?:[/-](?:[JFMASOND][a-z]+\.?|Winter))? +)?\d{4}(?:/(?:\d{4}|\d\d)\b)?))\)?`)
 	volumeNumberRegExp     = regexp.MustCompile(`, *Vol.[# ]*([^,]+),`)
+	intervieweeRegExp      = regexp.MustCompile(`(?i),? *\(interviews? with ((?:[^()]|\([^()]*\))+)\)`) // allows one level of nested parentheses
 	issueNumberRegExp      = regexp.MustCompile(`, *Iss.[# ]*([^,]+),`)
 )
 
@@ -100,14 +102,15 @@ func (e textEntry) movieTitleDetails(movie *Movie) {
 
 	if len(results) >= 1 {
 		movie.Title = e.cleanTitle(results[1])
+		movie.Series = movie.Title != results[1]
 	}
 
 	if len(results) >= 2 {
-		movie.Year, _ = strconv.Atoi(results[2])
+		movie.Year = e.titleYear(results[2])
 	}
 
 	if len(results) >= 3 {
-		movie.Month = e.monthFromRomanNumeral(results[3])
+		movie.Disambiguator = results[3]
 	}
 
 	if strings.Contains(m, "(TV)") {
@@ -115,6 +118,39 @@ func (e textEntry) movieTitleDetails(movie *Movie) {
 	}
 
 	e.seriesAndSubtitle(movie, m)
+
+	if len(results) > 0 {
+		movie.Kind = e.titleKind(movie, m[len(results[0]):])
+	}
+}
+
+// titleKind returns the kind of title, from the details following the title and year.
+func (e textEntry) titleKind(movie *Movie, details string) Kind {
+	details = strings.TrimSuffix(details, "{{SUSPENDED}}")
+
+	switch {
+	case movie.Series && strings.Contains(details, "{"):
+		return KindTVEpisode
+	case movie.Series:
+		return KindTVSeries
+	case strings.Contains(details, "(VG)"):
+		return KindVideoGame
+	case strings.Contains(details, "(V)"):
+		return KindVideo
+	case strings.Contains(details, "(TV)"):
+		return KindTVMovie
+	default:
+		return KindFeature
+	}
+}
+
+// titleYear returns the year, or UnknownYear when given as `????`.
+func (e textEntry) titleYear(year string) int {
+	if strings.Contains(year, "?") {
+		return UnknownYear
+	}
+	y, _ := strconv.Atoi(year)
+	return y
 }
 
 func (e textEntry) cleanTitle(title string) string {
@@ -125,8 +161,10 @@ func (e textEntry) cleanTitle(title string) string {
 }
 
 func (e textEntry) seriesAndSubtitle(movie *Movie, movieLine string) {
+	// records IMDb has pulled are marked as `{{SUSPENDED}}`
 	if strings.HasSuffix(movieLine, "{{SUSPENDED}}") {
-		return
+		movie.Suspended = true
+		movieLine = strings.TrimSpace(strings.TrimSuffix(movieLine, "{{SUSPENDED}}"))
 	}
 
 	// extract subtitle and series.episode info: `{Venjança (#1.6)}`
@@ -146,6 +184,17 @@ func (e textEntry) seriesAndSubtitle(movie *Movie, movieLine string) {
 		return
 	}
 
+	// extract the episode air date: `{(2003-05-12)}`
+	matches = seriesAirDate.FindStringSubmatch(movieLine)
+	if len(matches) == 5 {
+		movie.SeriesName = matches[1]
+		movie.AirDate.Year, _ = strconv.Atoi(matches[2])
+		movie.AirDate.Month, _ = strconv.Atoi(matches[3])
+		movie.AirDate.Day, _ = strconv.Atoi(matches[4])
+		movie.AirDate.Precision = PrecisionDay
+		return
+	}
+
 	// extract series info: `{A Clockwork Orange im Ballhof}`
 	matches = seriesNameOnly.FindStringSubmatch(movieLine)
 	if len(matches) == 2 {
@@ -154,10 +203,6 @@ func (e textEntry) seriesAndSubtitle(movie *Movie, movieLine string) {
 	}
 }
 
-func (e textEntry) monthFromRomanNumeral(romanNumeral string) int {
-	return monthRomanToInt[strings.ToLower(romanNumeral)]
-}
-
 func (e textEntry) adaptations(movie *Movie) {
 	for _, text := range e[ADPT] {
 		a := Adaptation{}
@@ -191,13 +236,14 @@ func (e textEntry) novels(movie *Movie) {
 // should go before positional based (e.g. Author, Title).
 func (e textEntry) bookParser(book *Book, data string) {
 	data = e.cleanSurroundingBraces(data)
+	book.MediaType = e.extractMediaType(data)
 	data = e.cleanRandomText(data)
 
 	book.MiscInfo, data = e.extractIn(data)
 	book.Volume, data = e.extractVolumeNumber(data)
 	book.Issue, data = e.extractIssueNumber(data)
 
-	book.ISBN, data = e.extractISBN(data)
+	book.ISBN, book.ISSN, data = e.extractISBN(data)
 	book.PageCount, data = e.extractPageCount(data)
 
 	// NOTE: must be done before other date processing
@@ -208,23 +254,13 @@ func (e textEntry) bookParser(book *Book, data string) {
 	// the items below are positional based
 
 	book.Author, data = e.extractAuthor(data)
+	book.Contributors = parseContributors(book.Author)
 	book.Title, data = e.extractTitle(data)
 
 	book.Publisher, data = e.extractPublisher(data)
 	book.Note, data = e.extractNotes(data)
 }
 
-var months = []string{"january", "february", "march", "april", "may", "june", "july", "august", "september", "october", "november", "december"}
-
-func (e textEntry) monthAsNumber(month string) int {
-	for i, m := range months {
-		if strings.ToLower(month) == m {
-			return i + 1
-		}
-	}
-	return 0
-}
-
 func (e textEntry) critiques(movie *Movie) {
 	for _, text := range e[CRIT] {
 		novel := Critique{}
@@ -244,6 +280,7 @@ func (e textEntry) essays(movie *Movie) {
 func (e textEntry) interviews(movie *Movie) {
 	for _, text := range e[IVIW] {
 		interview := Interview{}
+		interview.ArticleInterviewee, text = e.extractInterviewee(text)
 		e.publicationParser(&interview.Publication, text)
 		movie.Interviews = append(movie.Interviews, interview)
 	}
@@ -279,12 +316,14 @@ func (e textEntry) screenplays(movie *Movie) {
 // such as author names), to be done last.
 func (e textEntry) publicationParser(pub *Publication, data string) {
 	data = e.cleanSurroundingBraces(data)
+	pub.MediaType = e.extractMediaType(data)
 	data = e.cleanRandomText(data)
 
 	pub.Volume, data = e.extractVolumeNumber(data)
 	pub.Issue, data = e.extractIssueNumber(data)
-	pub.ISSN, data = e.extractISBN(data)
+	pub.ISBN, pub.ISSN, data = e.extractISBN(data)
 	pub.ArticlePages, data = e.extractPageRange(data)
+	pub.Pages = ParsePages(pub.ArticlePages)
 
 	pub.Name, data = e.extractIn(data)
 	pub.Date, data = e.extractPublishedDate(data)
@@ -293,11 +332,26 @@ func (e textEntry) publicationParser(pub *Publication, data string) {
 	// the items below are positional based
 	//
 	pub.ArticleAuthor, data = e.extractAuthor(data)
+	pub.Contributors = parseContributors(pub.ArticleAuthor)
 	pub.ArticleTitle, data = e.extractTitle(data)
 
 	pub.Publisher, data = e.extractPublisher(data)
 }
 
+// extractInterviewee from the text, based on the `(interview with X)` note,
+// which is only used by IVIW entries.
+func (e textEntry) extractInterviewee(data string) (interviewee string, str string) {
+	results := intervieweeRegExp.FindStringSubmatch(data)
+	if len(results) < 2 {
+		return "", data
+	}
+	interviewee = strings.TrimSpace(results[1])
+	str = strings.Replace(data, results[0], "", 1)
+	str = strings.TrimSpace(str)
+
+	return
+}
+
 // extractAuthor from the text, based on its position in the text.
 func (e textEntry) extractAuthor(data string) (author string, str string) {
 	results := authorRegExp.FindStringSubmatch(data)
@@ -410,11 +464,12 @@ func (e textEntry) extractIn(data string) (in string, str string) {
 	return
 }
 
-// extractISBN from the text, based on the key
-func (e textEntry) extractISBN(data string) (isbn string, str string) {
+// extractISBN from the text, based on the `ISBN:` or `ISSN:` key, placing it
+// in the ISBN or ISSN according to its value.
+func (e textEntry) extractISBN(data string) (isbn ISBN, issn ISSN, str string) {
 	results := isbnRegExp.FindStringSubmatch(data)
 	if isbnRegExp.MatchString(data) {
-		isbn = strings.TrimSpace(results[1])
+		isbn, issn = standardNumbers(results[1], strings.TrimSpace(results[2]))
 	}
 	str = isbnRegExp.ReplaceAllString(data, "")
 	str = strings.TrimSpace(str)
@@ -465,23 +520,12 @@ func (e textEntry) extractFirstPublishedDate(data string) (year int, str string)
 	return
 }
 
-// extractPublishedDate from the text, based on a string match
+// extractPublishedDate from the text, based on a string match. See ParseDate
+// for the date forms handled.
 func (e textEntry) extractPublishedDate(data string) (date Date, str string) {
 	results := publishedRegExp.FindStringSubmatch(data)
 	if len(results) > 1 {
-		dates := strings.Split(results[1], " ")
-
-		// year will always be present, so just grab the last part
-		year, _ := strconv.Atoi(dates[len(dates)-1])
-		date.Year = year
-
-		if len(dates) == 3 {
-			day, _ := strconv.Atoi(dates[0])
-			date.Day = day
-			date.Month = e.monthAsNumber(dates[1])
-		} else if len(dates) == 2 {
-			date.Month = e.monthAsNumber(dates[0])
-		}
+		date, _ = ParseDate(results[1])
 	}
 	str = publishedRegExp.ReplaceAllString(data, "")
 	str = strings.TrimSpace(str)
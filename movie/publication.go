@@ -0,0 +1,73 @@
+package movie
+
+import "strings"
+
+// PublicationQuery is used to search the publication entries (CRIT, ESSY,
+// IVIW, OTHR, PROT, SCRP) of a movie. Empty fields are ignored, and an entry
+// must match all the others.
+type PublicationQuery struct {
+	Name        string // publication name, e.g. "Sight and Sound"
+	Author      string // article author, matched the same way as book authors
+	Interviewee string // interview subject of an IVIW entry, matched the same way as book authors
+	From        Date   // earliest publication date, matching entries dated any time on or after its start
+	To          Date   // latest publication date, matching entries dated any time on or before its end
+	Types       []Key  // entry types, e.g. CRIT, ESSY
+
+	MediaTypes []MediaType // e.g. MediaMagazine, MediaNewspaper
+}
+
+// MatchPublications returns all the publication entries matching the query.
+func (m *Movie) MatchPublications(q PublicationQuery) []PublicationEntry {
+	var entries []PublicationEntry
+
+	for _, p := range m.PublicationEntries() {
+		if q.matches(m, p) {
+			entries = append(entries, p)
+		}
+	}
+
+	return entries
+}
+
+func (q PublicationQuery) matches(m *Movie, p PublicationEntry) bool {
+	if len(q.Types) > 0 && !q.hasType(p.Entry) {
+		return false
+	}
+	if len(q.MediaTypes) > 0 && !q.hasMediaType(p.MediaType) {
+		return false
+	}
+	if len(q.Name) > 0 && !strings.Contains(strings.ToLower(p.Name), strings.ToLower(q.Name)) {
+		return false
+	}
+	if len(q.Author) > 0 && (len(p.ArticleAuthor) == 0 || !m.authorMatches(NormaliseAuthor(p.ArticleAuthor), NormaliseAuthor(q.Author))) {
+		return false
+	}
+	if len(q.Interviewee) > 0 && (len(p.ArticleInterviewee) == 0 || !m.authorMatches(NormaliseAuthor(p.ArticleInterviewee), NormaliseAuthor(q.Interviewee))) {
+		return false
+	}
+	if q.From.Year > 0 && (p.Date.Year == 0 || p.Date.endsBefore(q.From)) {
+		return false
+	}
+	if q.To.Year > 0 && (p.Date.Year == 0 || q.To.endsBefore(p.Date)) {
+		return false
+	}
+	return true
+}
+
+func (q PublicationQuery) hasType(key Key) bool {
+	for _, k := range q.Types {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (q PublicationQuery) hasMediaType(mediaType MediaType) bool {
+	for _, m := range q.MediaTypes {
+		if m == mediaType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+package imdblit
+
+import "github.com/mrcook/imdblit/movie"
+
+// SearchOption configures a search, such as filtering which movies are returned.
+type SearchOption func(*searchOptions)
+
+type searchOptions struct {
+	yearFrom    int
+	yearTo      int
+	tv          *bool
+	series      *bool
+	episodeInfo *bool
+	kinds       []movie.Kind
+	suspended   *bool
+
+	aliases    *Aliases
+	pseudonyms *Pseudonyms
+	matcher    movie.Matcher
+
+	sort   SortOrder
+	offset int
+	limit  int
+}
+
+// WithYears only returns movies released between the given years, inclusive.
+// A zero from or to leaves that end of the range open. Movies with an unknown
+// year are never returned.
+func WithYears(from, to int) SearchOption {
+	return func(o *searchOptions) {
+		o.yearFrom = from
+		o.yearTo = to
+	}
+}
+
+// WithTV only returns TV movies/episodes when true, or only non-TV movies when false.
+func WithTV(tv bool) SearchOption {
+	return func(o *searchOptions) {
+		o.tv = &tv
+	}
+}
+
+// WithSeries only returns TV series titles when true, or only standalone
+// titles when false.
+func WithSeries(series bool) SearchOption {
+	return func(o *searchOptions) {
+		o.series = &series
+	}
+}
+
+// WithEpisodeInfo only returns movies with a series name, series number,
+// episode number, or air date when true, or only movies with none of them when false.
+func WithEpisodeInfo(present bool) SearchOption {
+	return func(o *searchOptions) {
+		o.episodeInfo = &present
+	}
+}
+
+// WithKinds only returns movies of any of the given kinds, such as
+// movie.KindFeature or movie.KindTVEpisode.
+func WithKinds(kinds ...movie.Kind) SearchOption {
+	return func(o *searchOptions) {
+		o.kinds = kinds
+	}
+}
+
+// WithSuspended only returns titles IMDb has suspended when true, or only
+// titles which are not suspended when false.
+func WithSuspended(suspended bool) SearchOption {
+	return func(o *searchOptions) {
+		o.suspended = &suspended
+	}
+}
+
+// WithPhoneticAuthors also matches author names that sound the same, such as
+// the transliterations Tolstoy/Tolstoi and Chekhov/Tchekhov.
+func WithPhoneticAuthors() SearchOption {
+	return func(o *searchOptions) {
+		o.matcher.PhoneticAuthors = true
+	}
+}
+
+func newSearchOptions(opts []SearchOption) *searchOptions {
+	o := &searchOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// accept reports whether the movie passes all the filters. Only the MOVI
+// details are used, so this can be called before the other entries are processed.
+func (o *searchOptions) accept(m *movie.Movie) bool {
+	if o.yearFrom > 0 || o.yearTo > 0 {
+		if m.Year <= 0 || m.Year < o.yearFrom || (o.yearTo > 0 && m.Year > o.yearTo) {
+			return false
+		}
+	}
+	if o.tv != nil && m.TV != *o.tv {
+		return false
+	}
+	if o.series != nil && m.Series != *o.series {
+		return false
+	}
+	if o.episodeInfo != nil {
+		present := isEpisode(m)
+		if present != *o.episodeInfo {
+			return false
+		}
+	}
+	if len(o.kinds) > 0 && !hasMovieKind(o.kinds, m.Kind) {
+		return false
+	}
+	if o.suspended != nil && m.Suspended != *o.suspended {
+		return false
+	}
+	return true
+}
+
+func hasMovieKind(kinds []movie.Kind, kind movie.Kind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+package imdblit
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Pseudonyms is a registry of the names an author published under, such as
+// "Mark Twain" and "Samuel Clemens". All names registered together belong to
+// the same author, and when used with WithPseudonyms, an adaptation or author
+// search for any one of them will also search for the others.
+//
+// Names are looked up as a whole, ignoring case, punctuation, and the order
+// of the names, so "Clemens, Samuel" is the same as "Samuel Clemens", but
+// "Twain" will not find "Mark Twain".
+//
+// Pseudonyms is safe for concurrent use.
+type Pseudonyms struct {
+	registry
+}
+
+// defaultPseudonyms is the built-in seed list, keyed by the best known name.
+// The real name is given both with and without any middle names, as entries
+// are often credited to either.
+var defaultPseudonyms = map[string][]string{
+	"Mark Twain":         {"Samuel Clemens", "Samuel Langhorne Clemens"},
+	"George Eliot":       {"Mary Ann Evans", "Marian Evans"},
+	"George Orwell":      {"Eric Blair", "Eric Arthur Blair"},
+	"Lewis Carroll":      {"Charles Dodgson", "Charles Lutwidge Dodgson"},
+	"O. Henry":           {"William Porter", "William Sydney Porter"},
+	"Charlotte Brontë":   {"Currer Bell"},
+	"Emily Brontë":       {"Ellis Bell"},
+	"Anne Brontë":        {"Acton Bell"},
+	"George Sand":        {"Aurore Dupin", "Amantine Lucile Aurore Dupin"},
+	"Voltaire":           {"François-Marie Arouet"},
+	"Stendhal":           {"Marie-Henri Beyle"},
+	"Molière":            {"Jean-Baptiste Poquelin"},
+	"Maxim Gorky":        {"Alexei Peshkov", "Alexei Maximovich Peshkov"},
+	"Joseph Conrad":      {"Józef Korzeniowski", "Józef Teodor Konrad Korzeniowski"},
+	"Isak Dinesen":       {"Karen Blixen"},
+	"Dr. Seuss":          {"Theodor Geisel", "Theodor Seuss Geisel"},
+	"John le Carré":      {"David Cornwell", "David John Moore Cornwell"},
+	"Ed McBain":          {"Evan Hunter", "Salvatore Lombino"},
+	"Ross Macdonald":     {"Kenneth Millar"},
+	"Stephen King":       {"Richard Bachman"},
+	"Agatha Christie":    {"Mary Westmacott"},
+	"J.K. Rowling":       {"Robert Galbraith"},
+	"Lemony Snicket":     {"Daniel Handler"},
+	"John Russell Fearn": {"Vargo Statten", "Volsted Gridban"},
+}
+
+// NewPseudonyms returns an empty pseudonym registry.
+func NewPseudonyms() *Pseudonyms {
+	return &Pseudonyms{registry: newRegistry(pseudonymKey)}
+}
+
+// DefaultPseudonyms returns a new pseudonym registry, seeded with the
+// built-in list of well known pen names.
+func DefaultPseudonyms() *Pseudonyms {
+	p := NewPseudonyms()
+	for name, pseudonyms := range defaultPseudonyms {
+		p.Add(name, pseudonyms...)
+	}
+	return p
+}
+
+// LoadPseudonyms returns a new pseudonym registry, seeded with the built-in
+// list, and then loaded from JSON, as described in Load.
+func LoadPseudonyms(r io.Reader) (*Pseudonyms, error) {
+	p := DefaultPseudonyms()
+	if err := p.Load(r); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Load adds the pseudonyms from a JSON object, where each key is an author,
+// and its value the list of other names they published under:
+//
+//	{"Mark Twain": ["Samuel Clemens", "Sieur Louis de Conte"]}
+func (p *Pseudonyms) Load(r io.Reader) error {
+	if err := p.load(r); err != nil {
+		return fmt.Errorf("loading pseudonyms: %w", err)
+	}
+	return nil
+}
+
+// Add registers the pseudonyms for the author. If any of the names are
+// already registered, all of them are merged together.
+func (p *Pseudonyms) Add(name string, pseudonyms ...string) {
+	p.add(append([]string{name}, pseudonyms...)...)
+}
+
+// Names returns the name followed by all the other names of the author, in
+// the order they were registered.
+func (p *Pseudonyms) Names(name string) []string {
+	if p == nil {
+		return []string{name}
+	}
+	return p.names(name)
+}
+
+// pseudonymKey ignores case, accents, punctuation, and the order of the names,
+// using the same words as the Store indexes, e.g. "Smith, Blythe" is "Blythe Smith".
+func pseudonymKey(name string) string {
+	names := words(name)
+	sort.Strings(names)
+	return strings.Join(names, " ")
+}
+
+// WithPseudonyms expands the author of an adaptation or author search to
+// include all the names in the registry for that author. The Match of each
+// result gives the pseudonym that matched, if any.
+func WithPseudonyms(p *Pseudonyms) SearchOption {
+	return func(o *searchOptions) {
+		o.pseudonyms = p
+	}
+}
@@ -0,0 +1,131 @@
+package imdblit_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	imdb "github.com/mrcook/imdblit"
+)
+
+var pseudonymsText = imdbText[:strings.Index(imdbText, "MOVI:")] + `MOVI: Huckleberry Finn (1974)
+
+NOVL: Twain, Mark. "Adventures of Huckleberry Finn"
+
+-------------------------------------------------------------------------------
+MOVI: Tom Sawyer (1973)
+
+NOVL: Clemens, Samuel. "Adventures of Tom Sawyer, The"
+
+-------------------------------------------------------------------------------
+MOVI: Middlemarch (1994) (TV)
+
+NOVL: Evans, Mary Ann. "Middlemarch"
+`
+
+func TestPseudonyms(t *testing.T) {
+	pseudonyms, err := imdb.LoadPseudonyms(strings.NewReader(`{"Mark Twain": ["Sieur Louis de Conte"]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	names := pseudonyms.Names("Clemens, Samuel")
+	if len(names) != 4 || names[0] != "Clemens, Samuel" {
+		t.Fatalf("expected the name and 3 pseudonyms, got %v", names)
+	}
+
+	if names := imdb.NewPseudonyms().Names("Mark Twain"); len(names) != 1 {
+		t.Errorf("expected no pseudonyms in an empty registry, got %v", names)
+	}
+	if names := pseudonyms.Names("Twain"); len(names) != 1 {
+		t.Errorf("expected a partial name to have no pseudonyms, got %v", names)
+	}
+
+	if _, err := imdb.LoadPseudonyms(strings.NewReader(`["Mark Twain"]`)); err == nil {
+		t.Errorf("expected invalid JSON to return an error")
+	}
+}
+
+func TestPseudonymsNameOrder(t *testing.T) {
+	pseudonyms := imdb.NewPseudonyms()
+	pseudonyms.Add("Blythe Smith", "Heather Brown")
+
+	testItems := []struct {
+		name  string
+		names []string
+	}{
+		{name: "Blythe Smith", names: []string{"Blythe Smith", "Heather Brown"}},
+		{name: "Smith, Blythe", names: []string{"Smith, Blythe", "Heather Brown"}},
+		{name: "BROWN, Heather", names: []string{"BROWN, Heather", "Blythe Smith"}},
+		{name: "Bly Smith", names: []string{"Bly Smith"}},
+	}
+
+	for i, item := range testItems {
+		names := pseudonyms.Names(item.name)
+		if len(names) != len(item.names) {
+			t.Errorf("(#%d) expected %d names, got %v", i, len(item.names), names)
+			continue
+		}
+		for j, name := range names {
+			if name != item.names[j] {
+				t.Errorf("(#%d) unexpected name, got '%s'", i, name)
+			}
+		}
+	}
+}
+
+func TestMovieAdaptationsWithPseudonyms(t *testing.T) {
+	pseudonyms := imdb.DefaultPseudonyms()
+
+	db := imdb.NewIMDB(bytes.NewBufferString(pseudonymsText))
+	results, err := db.FindMovieAdaptationMatches("Middlemarch", "George Eliot", imdb.WithPseudonyms(pseudonyms))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 movie to be found, got %d", len(results))
+	}
+	if results[0].Match.Pseudonym != "Mary Ann Evans" {
+		t.Errorf("expected the pseudonym to match, got '%s'", results[0].Match.Pseudonym)
+	}
+
+	db = imdb.NewIMDB(bytes.NewBufferString(pseudonymsText))
+	movies, err := db.FindMovieAdaptations("Middlemarch", "George Eliot")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(movies) != 0 {
+		t.Errorf("expected no movies to be found without pseudonyms, got %d", len(movies))
+	}
+}
+
+func TestAdaptationsByAuthorWithPseudonyms(t *testing.T) {
+	pseudonyms := imdb.DefaultPseudonyms()
+
+	db := imdb.NewIMDB(bytes.NewBufferString(pseudonymsText))
+	works, err := db.FindAdaptationsByAuthor("Mark Twain", imdb.WithPseudonyms(pseudonyms))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(works) != 2 {
+		t.Fatalf("expected 2 works to be found, got %d", len(works))
+	}
+	if works[0].Movies[0].Match.Pseudonym != "" {
+		t.Errorf("expected the query author to match, got pseudonym '%s'", works[0].Movies[0].Match.Pseudonym)
+	}
+	if works[1].Movies[0].Match.Pseudonym != "Samuel Clemens" {
+		t.Errorf("expected the pseudonym to match, got '%s'", works[1].Movies[0].Match.Pseudonym)
+	}
+
+	store, err := imdb.NewStore(imdb.NewIMDB(bytes.NewBufferString(pseudonymsText)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	works, err = store.FindAdaptationsByAuthor("Samuel Clemens", imdb.WithPseudonyms(pseudonyms))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(works) != 2 {
+		t.Errorf("expected 2 works to be found in the store, got %d", len(works))
+	}
+}
@@ -0,0 +1,169 @@
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mrcook/imdblit/movie"
+)
+
+type predicate func(m *movie.Movie) bool
+
+// fields maps each field name to a func that compiles its value into a predicate.
+var fields = map[string]func(value string) (predicate, error){
+	"title":       titleField,
+	"author":      authorField,
+	"book":        bookField,
+	"year":        yearField,
+	"tv":          tvField,
+	"series":      seriesField,
+	"kind":        kindField,
+	"type":        typeField,
+	"isbn":        isbnField,
+	"publication": publicationField,
+	"interviewee": intervieweeField,
+}
+
+func titleField(value string) (predicate, error) {
+	value = strings.ToLower(value)
+	return func(m *movie.Movie) bool {
+		return strings.Contains(strings.ToLower(m.Title), value)
+	}, nil
+}
+
+func authorField(value string) (predicate, error) {
+	if len(strings.TrimSpace(value)) == 0 {
+		return nil, fmt.Errorf("an author name is required")
+	}
+	return func(m *movie.Movie) bool {
+		return len(m.MatchAuthor(value)) > 0
+	}, nil
+}
+
+func bookField(value string) (predicate, error) {
+	return func(m *movie.Movie) bool {
+		return m.IsAdaptation(value, "")
+	}, nil
+}
+
+func yearField(value string) (predicate, error) {
+	from, to, err := parseYearRange(value)
+	if err != nil {
+		return nil, err
+	}
+	return func(m *movie.Movie) bool {
+		return m.Year > 0 && m.Year >= from && m.Year <= to
+	}, nil
+}
+
+// parseYearRange parses `1999`, `1990..2010`, `1990..`, or `..2010`.
+func parseYearRange(value string) (from, to int, err error) {
+	parts := strings.SplitN(value, "..", 2)
+	if len(parts) == 1 {
+		year, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, 0, fmt.Errorf("'%s' is not a year", value)
+		}
+		return year, year, nil
+	}
+
+	from, to = 0, 9999
+	if len(parts[0]) > 0 {
+		if from, err = strconv.Atoi(parts[0]); err != nil {
+			return 0, 0, fmt.Errorf("'%s' is not a year", parts[0])
+		}
+	}
+	if len(parts[1]) > 0 {
+		if to, err = strconv.Atoi(parts[1]); err != nil {
+			return 0, 0, fmt.Errorf("'%s' is not a year", parts[1])
+		}
+	}
+	if from > to {
+		return 0, 0, fmt.Errorf("year range %d..%d is backwards", from, to)
+	}
+
+	return from, to, nil
+}
+
+func tvField(value string) (predicate, error) {
+	tv, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("expected true or false")
+	}
+	return func(m *movie.Movie) bool {
+		return m.TV == tv
+	}, nil
+}
+
+func seriesField(value string) (predicate, error) {
+	value = strings.ToLower(value)
+	return func(m *movie.Movie) bool {
+		return len(m.SeriesName) > 0 && strings.Contains(strings.ToLower(m.SeriesName), value)
+	}, nil
+}
+
+func kindField(value string) (predicate, error) {
+	kind := movie.Kind(strings.ToLower(value))
+	switch kind {
+	case movie.KindFeature, movie.KindTVMovie, movie.KindVideo, movie.KindVideoGame, movie.KindTVSeries, movie.KindTVEpisode:
+		return func(m *movie.Movie) bool {
+			return m.Kind == kind
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown kind '%s'", value)
+	}
+}
+
+func typeField(value string) (predicate, error) {
+	key := movie.Key(strings.ToUpper(value))
+	switch key {
+	case movie.ADPT, movie.BOOK, movie.NOVL:
+		return func(m *movie.Movie) bool {
+			for _, b := range m.BookEntries() {
+				if b.Entry == key {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case movie.CRIT, movie.ESSY, movie.IVIW, movie.OTHR, movie.PROT, movie.SCRP:
+		return func(m *movie.Movie) bool {
+			for _, p := range m.PublicationEntries() {
+				if p.Entry == key {
+					return true
+				}
+			}
+			return false
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown entry type '%s'", value)
+	}
+}
+
+func isbnField(value string) (predicate, error) {
+	if _, err := movie.NormaliseISBN(value); err != nil {
+		return nil, err
+	}
+	return func(m *movie.Movie) bool {
+		_, ok := m.MatchISBN(value)
+		return ok
+	}, nil
+}
+
+func publicationField(value string) (predicate, error) {
+	q := movie.PublicationQuery{Name: value}
+	return func(m *movie.Movie) bool {
+		return len(m.MatchPublications(q)) > 0
+	}, nil
+}
+
+func intervieweeField(value string) (predicate, error) {
+	if len(strings.TrimSpace(value)) == 0 {
+		return nil, fmt.Errorf("an interviewee name is required")
+	}
+	q := movie.PublicationQuery{Interviewee: value}
+	return func(m *movie.Movie) bool {
+		return len(m.MatchPublications(q)) > 0
+	}, nil
+}
@@ -0,0 +1,103 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenWord
+	tokenString
+	tokenColon
+	tokenLeftParen
+	tokenRightParen
+)
+
+func (t tokenType) String() string {
+	switch t {
+	case tokenEOF:
+		return "end of query"
+	case tokenWord:
+		return "word"
+	case tokenString:
+		return "quoted string"
+	case tokenColon:
+		return "':'"
+	case tokenLeftParen:
+		return "'('"
+	case tokenRightParen:
+		return "')'"
+	default:
+		return "unknown token"
+	}
+}
+
+type token struct {
+	typ   tokenType
+	value string
+	pos   int // 1-based position of the first character
+}
+
+// lex splits the query text into tokens, the last of which is always tokenEOF.
+func lex(text string) ([]token, error) {
+	var tokens []token
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == ':':
+			tokens = append(tokens, token{typ: tokenColon, value: ":", pos: pos})
+			i++
+		case r == '(':
+			tokens = append(tokens, token{typ: tokenLeftParen, value: "(", pos: pos})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{typ: tokenRightParen, value: ")", pos: pos})
+			i++
+		case r == '"':
+			value, next, ok := lexString(runes, i)
+			if !ok {
+				return nil, &SyntaxError{Pos: pos, Msg: "unterminated quoted string"}
+			}
+			tokens = append(tokens, token{typ: tokenString, value: value, pos: pos})
+			i = next
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune(`:()"`, runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{typ: tokenWord, value: string(runes[start:i]), pos: pos})
+		}
+	}
+
+	return append(tokens, token{typ: tokenEOF, pos: len(runes) + 1}), nil
+}
+
+// lexString reads a double quoted string starting at runes[start], where
+// a `\"` is an escaped quote. Returns the unquoted value and the index after
+// the closing quote.
+func lexString(runes []rune, start int) (string, int, bool) {
+	var b strings.Builder
+
+	for i := start + 1; i < len(runes); i++ {
+		switch {
+		case runes[i] == '\\' && i+1 < len(runes) && runes[i+1] == '"':
+			b.WriteRune('"')
+			i++
+		case runes[i] == '"':
+			return b.String(), i + 1, true
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+
+	return "", 0, false
+}
@@ -0,0 +1,152 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parser is a recursive descent parser for the grammar:
+//
+//	query   = or
+//	or      = and { "OR" and }
+//	and     = not { "AND" not }
+//	not     = "NOT" not | primary
+//	primary = "(" or ")" | field ":" value
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) parse() (node, error) {
+	if p.peek().typ == tokenEOF {
+		return nil, &SyntaxError{Pos: p.peek().pos, Msg: "empty query"}
+	}
+
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.typ != tokenEOF {
+		return nil, p.unexpected(t, "AND, OR, or end of query")
+	}
+
+	return n, nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword(p.peek(), "OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.isKeyword(p.peek(), "AND") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.isKeyword(p.peek(), "NOT") {
+		p.next()
+		n, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{node: n}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+
+	switch t.typ {
+	case tokenLeftParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.typ != tokenRightParen {
+			return nil, p.unexpected(closing, "')' to close the '(' at position "+fmt.Sprint(t.pos))
+		}
+		return n, nil
+	case tokenWord:
+		return p.parseTerm(t)
+	default:
+		return nil, p.unexpected(t, "a field name, NOT, or '('")
+	}
+}
+
+func (p *parser) parseTerm(field token) (node, error) {
+	name := strings.ToLower(field.value)
+	compile, ok := fields[name]
+	if !ok {
+		return nil, &SyntaxError{Pos: field.pos, Msg: fmt.Sprintf("unknown field '%s'", field.value)}
+	}
+
+	if t := p.next(); t.typ != tokenColon {
+		return nil, p.unexpected(t, "':' after field '"+field.value+"'")
+	}
+
+	value := p.next()
+	if value.typ != tokenWord && value.typ != tokenString {
+		return nil, p.unexpected(value, "a value for field '"+field.value+"'")
+	}
+
+	pred, err := compile(value.value)
+	if err != nil {
+		return nil, &SyntaxError{Pos: value.pos, Msg: fmt.Sprintf("invalid value for field '%s': %s", field.value, err)}
+	}
+
+	return pred, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isKeyword(t token, keyword string) bool {
+	return t.typ == tokenWord && strings.ToUpper(t.value) == keyword
+}
+
+func (p *parser) unexpected(t token, expected string) error {
+	found := t.typ.String()
+	if t.typ == tokenWord || t.typ == tokenString {
+		found = fmt.Sprintf("'%s'", t.value)
+	}
+	return &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("expected %s, found %s", expected, found)}
+}
@@ -0,0 +1,107 @@
+// Package query provides a small query language for filtering IMDB movie
+// records, such as:
+//
+//	author:"Austen" AND year:1990..2010 AND NOT tv:true AND type:NOVL
+//
+// A query is made up of `field:value` terms, which can be combined with the
+// AND, OR, and NOT operators, and grouped with parentheses. Values containing
+// spaces or special characters must be double quoted.
+//
+// Available fields:
+//
+//	title:        movie title contains the value
+//	author:       author of an ADPT, BOOK, or NOVL entry matches the value
+//	book:         title of an ADPT, BOOK, or NOVL entry contains the value
+//	year:         movie year, either `1999`, or a range `1990..2010`, `1990..`, `..2010`
+//	tv:           `true` or `false`
+//	series:       movie series name contains the value
+//	kind:         one of `feature`, `tv-movie`, `video`, `video-game`, `tv-series`, or `tv-episode`
+//	type:         movie has an entry of the type, e.g. `NOVL`, `CRIT`
+//	isbn:         an entry has the ISBN, in ISBN-10 or ISBN-13 form
+//	publication:  name of a CRIT, ESSY, IVIW, OTHR, PROT, or SCRP entry contains the value
+//	interviewee:  interview subject of an IVIW entry matches the value
+//
+// Field names and operators are case-insensitive, as are all text matches.
+package query
+
+import (
+	"fmt"
+
+	"github.com/mrcook/imdblit/movie"
+)
+
+// Query is a compiled query, which can be matched against movies.
+type Query struct {
+	text string
+	root node
+}
+
+// Parse compiles the query text, returning a *SyntaxError when it is invalid.
+func Parse(text string) (*Query, error) {
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{tokens: tokens}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Query{text: text, root: root}, nil
+}
+
+// Match reports whether the movie satisfies the query.
+func (q *Query) Match(m *movie.Movie) bool {
+	return q.root.match(m)
+}
+
+// String returns the original query text.
+func (q *Query) String() string {
+	return q.text
+}
+
+// SyntaxError describes an invalid query, and the position at which the
+// problem was found.
+type SyntaxError struct {
+	Pos int // 1-based character position in the query text
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("query syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+type node interface {
+	match(m *movie.Movie) bool
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) match(m *movie.Movie) bool {
+	return n.left.match(m) && n.right.match(m)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) match(m *movie.Movie) bool {
+	return n.left.match(m) || n.right.match(m)
+}
+
+type notNode struct {
+	node node
+}
+
+func (n notNode) match(m *movie.Movie) bool {
+	return !n.node.match(m)
+}
+
+// a field:value term compiles to a predicate
+func (p predicate) match(m *movie.Movie) bool {
+	return p(m)
+}
@@ -0,0 +1,92 @@
+package query_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mrcook/imdblit/movie"
+	"github.com/mrcook/imdblit/query"
+)
+
+var records = []string{
+	`MOVI: Mansfield Park (1999)
+NOVL: Austen, Jane. "Mansfield Park"
+CRIT: Walker, John. In: "Total Film" (UK), July 1999, Pg. 106, (MG)`,
+	`MOVI: Mansfield Park (2007) (TV)
+NOVL: Austen, Jane. "Mansfield Park"`,
+	`MOVI: "A Taste of Shakespeare" (1995) {King Lear}
+ADPT: Shakespeare, William. "King Lear"
+IVIW: Weaver, Tom. "Lear Again". In: "Starlog" (USA), Iss. 180, July 1996 (interview with director Jane Doe)`,
+}
+
+func TestQueryMatching(t *testing.T) {
+	movies := make([]movie.Movie, len(records))
+	for i, r := range records {
+		movie.Unmarshall(r, &movies[i])
+	}
+
+	testItems := []struct {
+		query   string
+		matches []bool
+	}{
+		{query: `author:"Austen" AND year:1990..2010 AND NOT tv:true AND type:NOVL`, matches: []bool{true, false, false}},
+		{query: `author:austen OR series:lear`, matches: []bool{true, true, true}},
+		{query: `NOT (author:Austen AND year:2007)`, matches: []bool{true, false, true}},
+		{query: `book:"king lear" and type:adpt`, matches: []bool{false, false, true}},
+		{query: `year:..1995`, matches: []bool{false, false, true}},
+		{query: `year:2000..`, matches: []bool{false, true, false}},
+		{query: `publication:"Total Film"`, matches: []bool{true, false, false}},
+		{query: `title:"mansfield" AND tv:false`, matches: []bool{true, false, false}},
+		{query: `kind:TV-Movie OR kind:tv-episode`, matches: []bool{false, true, true}},
+		{query: `interviewee:"Doe, Jane"`, matches: []bool{false, false, true}},
+	}
+
+	for i, item := range testItems {
+		q, err := query.Parse(item.query)
+		if err != nil {
+			t.Fatalf("(#%d) unexpected error: %s", i, err)
+		}
+		for j, expected := range item.matches {
+			if q.Match(&movies[j]) != expected {
+				t.Errorf("(#%d) expected movie #%d match to be %t", i, j, expected)
+			}
+		}
+	}
+}
+
+func TestQuerySyntaxErrors(t *testing.T) {
+	testItems := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{query: ``, pos: 1, msg: "empty query"},
+		{query: `author:"Austen`, pos: 8, msg: "unterminated quoted string"},
+		{query: `writer:Austen`, pos: 1, msg: "unknown field 'writer'"},
+		{query: `author Austen`, pos: 8, msg: "expected ':' after field 'author', found 'Austen'"},
+		{query: `author:`, pos: 8, msg: "expected a value for field 'author', found end of query"},
+		{query: `year:199O`, pos: 6, msg: "invalid value for field 'year': '199O' is not a year"},
+		{query: `year:2010..1990`, pos: 6, msg: "invalid value for field 'year': year range 2010..1990 is backwards"},
+		{query: `tv:yes`, pos: 4, msg: "invalid value for field 'tv': expected true or false"},
+		{query: `type:FILM`, pos: 6, msg: "invalid value for field 'type': unknown entry type 'FILM'"},
+		{query: `kind:film`, pos: 6, msg: "invalid value for field 'kind': unknown kind 'film'"},
+		{query: `(tv:true OR year:1999`, pos: 22, msg: "expected ')' to close the '(' at position 1, found end of query"},
+		{query: `tv:true year:1999`, pos: 9, msg: "expected AND, OR, or end of query, found 'year'"},
+		{query: `tv:true AND`, pos: 12, msg: "expected a field name, NOT, or '(', found end of query"},
+	}
+
+	for i, item := range testItems {
+		_, err := query.Parse(item.query)
+
+		var syntaxErr *query.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Fatalf("(#%d) expected a syntax error, got %v", i, err)
+		}
+		if syntaxErr.Pos != item.pos {
+			t.Errorf("(#%d) unexpected error position, got %d", i, syntaxErr.Pos)
+		}
+		if syntaxErr.Msg != item.msg {
+			t.Errorf("(#%d) unexpected error message, got '%s'", i, syntaxErr.Msg)
+		}
+	}
+}
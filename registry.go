@@ -0,0 +1,98 @@
+package imdblit
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// registry holds groups of equivalent names, such as a title and its aliases,
+// where every name in a group is equivalent to all the others. Names are
+// compared using the key func. It is safe for concurrent use.
+type registry struct {
+	mu     sync.RWMutex
+	key    func(name string) string
+	groups map[string]*nameGroup // keyed by each name in the group
+}
+
+type nameGroup struct {
+	names []string
+}
+
+func newRegistry(key func(name string) string) registry {
+	return registry{key: key, groups: map[string]*nameGroup{}}
+}
+
+// add puts all the names into the same group. If any of the names are
+// already in a group, those groups are merged together.
+func (r *registry) add(names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	group := &nameGroup{}
+	for _, name := range names {
+		key := r.key(name)
+		if len(key) == 0 {
+			continue
+		}
+		existing, ok := r.groups[key]
+		if !ok {
+			r.addToGroup(group, name)
+			r.groups[key] = group
+			continue
+		}
+		if existing == group {
+			continue
+		}
+		// merge the existing group into the new one
+		for _, n := range existing.names {
+			r.addToGroup(group, n)
+			r.groups[r.key(n)] = group
+		}
+	}
+}
+
+func (r *registry) addToGroup(group *nameGroup, name string) {
+	key := r.key(name)
+	for _, n := range group.names {
+		if r.key(n) == key {
+			return
+		}
+	}
+	group.names = append(group.names, name)
+}
+
+// load adds the groups from a JSON object, where each key is a name, and its
+// value a list of the names equivalent to it.
+func (r *registry) load(rd io.Reader) error {
+	var groups map[string][]string
+	if err := json.NewDecoder(rd).Decode(&groups); err != nil {
+		return err
+	}
+	for name, equivalents := range groups {
+		r.add(append([]string{name}, equivalents...)...)
+	}
+	return nil
+}
+
+// names returns the name followed by all the names equivalent to it, in the
+// order they were added.
+func (r *registry) names(name string) []string {
+	names := []string{name}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key := r.key(name)
+	group, ok := r.groups[key]
+	if !ok {
+		return names
+	}
+	for _, n := range group.names {
+		if r.key(n) != key {
+			names = append(names, n)
+		}
+	}
+
+	return names
+}
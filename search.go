@@ -0,0 +1,230 @@
+package imdblit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mrcook/imdblit/movie"
+	"github.com/mrcook/imdblit/query"
+)
+
+// Result is a movie found by a search, along with a report of why it matched.
+type Result struct {
+	movie.Movie
+	Match    movie.Match
+	Position int // position of the record in the DB, starting at 0
+}
+
+// AdaptedWork is a source work, along with the movies adapted from it.
+type AdaptedWork struct {
+	Title  string // as given by the first matching entry
+	Movies []Result
+}
+
+// PublicationResult is a movie found by a publication search, along with the
+// entries that matched.
+type PublicationResult struct {
+	movie.Movie
+	Entries  []movie.PublicationEntry
+	Position int // position of the record in the DB, starting at 0
+}
+
+// eachMovie calls fn for every movie that is a candidate for a search, and
+// is accepted by the search options, along with the position of its record
+// in the DB. This allows the same searches to be used for both the IMDB and
+// the Store.
+type eachMovie func(accept func(*movie.Movie) bool, fn func(pos int, mov movie.Movie)) error
+
+func findMovieAdaptations(each eachMovie, title, author string, opts []SearchOption) ([]Result, error) {
+	var results []Result
+
+	o := newSearchOptions(opts)
+	titles := o.aliases.Titles(title)
+	authors := o.pseudonyms.Names(author)
+
+	err := each(o.accept, func(pos int, mov movie.Movie) {
+		if match, ok := matchAdaptation(o.matcher, &mov, titles, authors); ok {
+			results = append(results, Result{Movie: mov, Match: match, Position: pos})
+		}
+	})
+
+	return o.sortAndPaginate(results, SortYearDesc), err
+}
+
+// matchAdaptation returns the best match for any of the titles and authors,
+// where the first title is the query title and the others its aliases, and
+// the first author is the query author and the others their pseudonyms. On an
+// equal score the query title and author are preferred.
+func matchAdaptation(matcher movie.Matcher, mov *movie.Movie, titles, authors []string) (movie.Match, bool) {
+	var best movie.Match
+	found := false
+
+	for i, title := range titles {
+		for j, author := range authors {
+			match, ok := matcher.MatchAdaptation(mov, title, author)
+			if !ok || (found && match.Score <= best.Score) {
+				continue
+			}
+			if i > 0 {
+				match.Alias = title
+			}
+			if j > 0 {
+				match.Pseudonym = author
+			}
+			best = match
+			found = true
+		}
+	}
+
+	return best, found
+}
+
+// findAdaptationsByAuthor sorts the works by their normalised title, with the
+// pagination applied to the works, and the sort order to the movies of each work.
+func findAdaptationsByAuthor(each eachMovie, author string, opts []SearchOption) ([]AdaptedWork, error) {
+	if len(strings.TrimSpace(author)) == 0 {
+		return nil, fmt.Errorf("an author name is required")
+	}
+
+	var works []AdaptedWork
+	workIndex := map[string]int{}
+
+	o := newSearchOptions(opts)
+	authors := o.pseudonyms.Names(author)
+
+	err := each(o.accept, func(pos int, mov movie.Movie) {
+		seen := map[string]bool{}
+		for _, match := range matchAuthor(o.matcher, &mov, authors) {
+			if seen[match.Title] {
+				continue
+			}
+			seen[match.Title] = true
+
+			i, ok := workIndex[match.Title]
+			if !ok {
+				i = len(works)
+				workIndex[match.Title] = i
+				works = append(works, AdaptedWork{Title: entryTitle(mov, match)})
+			}
+			works[i].Movies = append(works[i].Movies, Result{Movie: mov, Match: match, Position: pos})
+		}
+	})
+
+	// all movies in a work share the same normalised title
+	sort.Slice(works, func(i, j int) bool {
+		return works[i].Movies[0].Match.Title < works[j].Movies[0].Match.Title
+	})
+
+	for i := range works {
+		o.sortResults(works[i].Movies, SortYearDesc)
+	}
+	start, end := o.page(len(works))
+
+	return works[start:end], err
+}
+
+// matchAuthor returns the matches for all the authors, where the first author
+// is the query author and the others their pseudonyms, with the matches for
+// the query author first.
+func matchAuthor(matcher movie.Matcher, mov *movie.Movie, authors []string) []movie.Match {
+	var matches []movie.Match
+	for i, author := range authors {
+		for _, match := range matcher.MatchAuthor(mov, author) {
+			if i > 0 {
+				match.Pseudonym = author
+			}
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+// entryTitle returns the original title of the book entry reported by the match.
+func entryTitle(mov movie.Movie, match movie.Match) string {
+	for _, b := range mov.BookEntries() {
+		if b.Entry == match.Entry && b.Index == match.Index {
+			return b.Title
+		}
+	}
+	return ""
+}
+
+func findByISBN(each eachMovie, isbn string, opts []SearchOption) ([]Result, error) {
+	if _, err := movie.NormaliseISBN(isbn); err != nil {
+		return nil, err
+	}
+
+	var results []Result
+
+	o := newSearchOptions(opts)
+	err := each(o.accept, func(pos int, mov movie.Movie) {
+		if match, ok := mov.MatchISBN(isbn); ok {
+			results = append(results, Result{Movie: mov, Match: match, Position: pos})
+		}
+	})
+
+	return o.sortAndPaginate(results, SortYearDesc), err
+}
+
+func findByQuery(each eachMovie, text string, opts []SearchOption) ([]movie.Movie, error) {
+	q, err := query.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return findMovies(each, opts, q.Match)
+}
+
+// findMovies returns the movies for which match returns true, in file order
+// unless another sort order is given.
+func findMovies(each eachMovie, opts []SearchOption, match func(*movie.Movie) bool) ([]movie.Movie, error) {
+	var results []Result
+
+	o := newSearchOptions(opts)
+	err := each(o.accept, func(pos int, mov movie.Movie) {
+		if match(&mov) {
+			results = append(results, Result{Movie: mov, Position: pos})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resultMovies(o.sortAndPaginate(results, SortFileOrder)), nil
+}
+
+func findPublications(each eachMovie, q movie.PublicationQuery, opts []SearchOption) ([]PublicationResult, error) {
+	var results []PublicationResult
+
+	o := newSearchOptions(opts)
+	err := each(o.accept, func(pos int, mov movie.Movie) {
+		if entries := mov.MatchPublications(q); len(entries) > 0 {
+			results = append(results, PublicationResult{Movie: mov, Entries: entries, Position: pos})
+		}
+	})
+
+	order := o.sortOrder(SortYearDesc)
+	c := order.collator()
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := &results[i], &results[j]
+		return order.less(c, &a.Movie, &b.Movie, 0, 0, a.Position, b.Position)
+	})
+
+	start, end := o.page(len(results))
+
+	return results[start:end], err
+}
+
+func resultMovies(results []Result) []movie.Movie {
+	if results == nil {
+		return nil
+	}
+
+	movies := make([]movie.Movie, len(results))
+	for i, r := range results {
+		movies[i] = r.Movie
+	}
+
+	return movies
+}
@@ -0,0 +1,159 @@
+package imdblit
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mrcook/imdblit/movie"
+)
+
+// Series is a TV series, with its episode records grouped by season, and the
+// literary sources of all its records merged together.
+type Series struct {
+	Title string
+	Year  int
+
+	Record  *Result  // the record for the series itself, if the DB has one
+	Seasons []Season // in season number order
+	Sources []SeriesSource
+}
+
+// Season holds the episodes of a series with the same series number. Episodes
+// without a series number are held in season 0.
+type Season struct {
+	Number   int
+	Episodes []Result // in episode number order, then file order
+}
+
+// SeriesSource is a literary source (ADPT, BOOK, or NOVL entry) of a series,
+// as given by the first record citing it.
+type SeriesSource struct {
+	movie.BookEntry
+	Positions []int // positions of all the records in the series citing it
+}
+
+// Episodes returns the total number of episode records in the series.
+func (s *Series) Episodes() int {
+	n := 0
+	for _, season := range s.Seasons {
+		n += len(season.Episodes)
+	}
+	return n
+}
+
+// groupSeries groups the records of all TV series by their series title and
+// year. The series are sorted by title, unless another order is given, with
+// any pagination applied to the series.
+func groupSeries(each eachMovie, opts []SearchOption) ([]Series, error) {
+	var series []Series
+	seriesIndex := map[seriesKey]int{}
+	sourceIndex := map[seriesKey]map[string]int{}
+
+	o := newSearchOptions(opts)
+	accept := func(m *movie.Movie) bool {
+		return m.Series && o.accept(m)
+	}
+
+	err := each(accept, func(pos int, mov movie.Movie) {
+		key := seriesKey{title: mov.Title, year: mov.Year}
+		i, ok := seriesIndex[key]
+		if !ok {
+			i = len(series)
+			seriesIndex[key] = i
+			sourceIndex[key] = map[string]int{}
+			series = append(series, Series{Title: mov.Title, Year: mov.Year})
+		}
+		s := &series[i]
+
+		result := Result{Movie: mov, Position: pos}
+		if isEpisode(&mov) {
+			s.addEpisode(result)
+		} else if s.Record == nil {
+			s.Record = &result
+		}
+
+		sources := sourceIndex[key]
+		for _, b := range mov.BookEntries() {
+			k := workKey(&b.Book)
+			j, ok := sources[k]
+			if !ok {
+				j = len(s.Sources)
+				sources[k] = j
+				s.Sources = append(s.Sources, SeriesSource{BookEntry: b})
+			}
+			if p := s.Sources[j].Positions; len(p) == 0 || p[len(p)-1] != pos {
+				s.Sources[j].Positions = append(p, pos)
+			}
+		}
+	})
+
+	for i := range series {
+		s := &series[i]
+		sort.Slice(s.Seasons, func(a, b int) bool {
+			return s.Seasons[a].Number < s.Seasons[b].Number
+		})
+		for _, season := range s.Seasons {
+			episodes := season.Episodes
+			sort.SliceStable(episodes, func(a, b int) bool {
+				return episodes[a].EpisodeNumber < episodes[b].EpisodeNumber
+			})
+		}
+	}
+
+	order := o.sortOrder(SortTitle)
+	c := order.collator()
+	sort.SliceStable(series, func(i, j int) bool {
+		a := movie.Movie{Title: series[i].Title, Year: series[i].Year}
+		b := movie.Movie{Title: series[j].Title, Year: series[j].Year}
+		return order.less(c, &a, &b, 0, 0, series[i].firstPosition(), series[j].firstPosition())
+	})
+
+	start, end := o.page(len(series))
+
+	return series[start:end], err
+}
+
+type seriesKey struct {
+	title string
+	year  int
+}
+
+// isEpisode reports whether the record is for an episode of a series, rather
+// than the series itself.
+func isEpisode(m *movie.Movie) bool {
+	return len(m.SeriesName) > 0 || m.SeriesNumber > 0 || m.EpisodeNumber > 0 || m.AirDate.Year > 0
+}
+
+func (s *Series) addEpisode(episode Result) {
+	for i := range s.Seasons {
+		if s.Seasons[i].Number == episode.SeriesNumber {
+			s.Seasons[i].Episodes = append(s.Seasons[i].Episodes, episode)
+			return
+		}
+	}
+	s.Seasons = append(s.Seasons, Season{Number: episode.SeriesNumber, Episodes: []Result{episode}})
+}
+
+// firstPosition returns the position of the first record of the series in the DB.
+func (s *Series) firstPosition() int {
+	pos := -1
+	if s.Record != nil {
+		pos = s.Record.Position
+	}
+	for _, season := range s.Seasons {
+		for _, e := range season.Episodes {
+			if pos < 0 || e.Position < pos {
+				pos = e.Position
+			}
+		}
+	}
+	return pos
+}
+
+// workKey identifies the literary work of a book by its title and authors,
+// ignoring case, punctuation, the word "the", and the order of the author
+// names. Only the contributors with the author role are used, so an edition
+// naming a translator or editor is the same work.
+func workKey(b *movie.Book) string {
+	return aliasKey(b.Title) + "\x00" + pseudonymKey(strings.Join(b.AuthorNames(), " "))
+}
@@ -0,0 +1,98 @@
+package imdblit_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	imdb "github.com/mrcook/imdblit"
+)
+
+var seriesText = imdbText[:strings.Index(imdbText, "MOVI:")] + `MOVI: "Sherlock Holmes" (1965) {The Copper Beeches (#1.2)}
+
+ADPT: Doyle, Arthur Conan. "Adventure of the Copper Beeches, The"
+
+-------------------------------------------------------------------------------
+MOVI: "Sherlock Holmes" (1965)
+
+NOVL: Doyle, Arthur Conan. "Adventures of Sherlock Holmes, The"
+
+-------------------------------------------------------------------------------
+MOVI: "Sherlock Holmes" (1965) {The Illustrious Client (#1.1)}
+
+ADPT: Doyle, Arthur Conan. "Adventure of the Illustrious Client, The"
+NOVL: Doyle, Arthur Conan. "Adventures of Sherlock Holmes, The"
+
+-------------------------------------------------------------------------------
+MOVI: "Sherlock Holmes" (1965) {The Hound of the Baskervilles: Part 1 (#2.1)}
+
+NOVL: Conan Doyle, Arthur. "Hound of the Baskervilles, The"
+
+-------------------------------------------------------------------------------
+MOVI: Sherlock Holmes (2009)
+
+ADPT: Doyle, Arthur Conan. "Sherlock Holmes"
+
+-------------------------------------------------------------------------------
+MOVI: "Emma" (1972) {(#1.1)}
+
+NOVL: Austen, Jane. "Emma"
+`
+
+func TestGroupSeries(t *testing.T) {
+	db := imdb.NewIMDB(bytes.NewBufferString(seriesText))
+	series, err := db.GroupSeries()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(series) != 2 {
+		t.Fatalf("expected 2 series, got %d", len(series))
+	}
+	if series[0].Title != "Emma" || series[1].Title != "Sherlock Holmes" {
+		t.Fatalf("expected the series to be sorted by title, got '%s' and '%s'", series[0].Title, series[1].Title)
+	}
+
+	s := series[1]
+	if s.Year != 1965 {
+		t.Errorf("expected year 1965, got %d", s.Year)
+	}
+	if s.Record == nil || s.Record.Position != 1 {
+		t.Errorf("expected the series record to be found")
+	}
+	if s.Episodes() != 3 {
+		t.Errorf("expected 3 episodes, got %d", s.Episodes())
+	}
+	if len(s.Seasons) != 2 || s.Seasons[0].Number != 1 || s.Seasons[1].Number != 2 {
+		t.Fatalf("expected seasons 1 and 2, got %+v", s.Seasons)
+	}
+	if e := s.Seasons[0].Episodes; len(e) != 2 || e[0].SeriesName != "The Illustrious Client" {
+		t.Errorf("expected the episodes to be in episode number order")
+	}
+
+	if len(s.Sources) != 4 {
+		t.Fatalf("expected 4 merged sources, got %d", len(s.Sources))
+	}
+	adventures := s.Sources[1]
+	if adventures.Title != "Adventures of Sherlock Holmes, The" || len(adventures.Positions) != 2 {
+		t.Errorf("expected a source cited by 2 records, got '%s' with positions %v", adventures.Title, adventures.Positions)
+	}
+}
+
+func TestGroupSeriesStore(t *testing.T) {
+	store, err := imdb.NewStore(imdb.NewIMDB(bytes.NewBufferString(seriesText)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	series, err := store.GroupSeries(imdb.WithYears(1970, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(series) != 1 || series[0].Title != "Emma" {
+		t.Fatalf("expected only the Emma series, got %d series", len(series))
+	}
+	if series[0].Record != nil {
+		t.Errorf("expected no series record")
+	}
+}
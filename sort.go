@@ -0,0 +1,116 @@
+package imdblit
+
+import (
+	"sort"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+
+	"github.com/mrcook/imdblit/movie"
+)
+
+// SortOrder is the order in which search results are returned. Results which
+// are equal in the given order are always returned in file order, so the same
+// search always returns the same results in the same order.
+type SortOrder int
+
+// List of all the available sort orders.
+const (
+	SortDefault   SortOrder = iota // the default for each search, usually SortYearDesc
+	SortYearDesc                   // newest movies first
+	SortYearAsc                    // oldest movies first
+	SortTitle                      // movie title, using language aware collation
+	SortScore                      // best match score first
+	SortFileOrder                  // the order the records appear in the DB
+)
+
+// WithSort sets the order of the search results.
+func WithSort(order SortOrder) SearchOption {
+	return func(o *searchOptions) {
+		o.sort = order
+	}
+}
+
+// WithPagination returns only limit results, after skipping the first offset
+// results. A zero limit returns all the remaining results.
+func WithPagination(offset, limit int) SearchOption {
+	return func(o *searchOptions) {
+		o.offset = offset
+		o.limit = limit
+	}
+}
+
+func (o *searchOptions) sortOrder(defaultOrder SortOrder) SortOrder {
+	if o.sort == SortDefault {
+		return defaultOrder
+	}
+	return o.sort
+}
+
+func (o *searchOptions) sortResults(results []Result, defaultOrder SortOrder) {
+	order := o.sortOrder(defaultOrder)
+	c := order.collator()
+
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := &results[i], &results[j]
+		return order.less(c, &a.Movie, &b.Movie, a.Match.Score, b.Match.Score, a.Position, b.Position)
+	})
+}
+
+func (o *searchOptions) sortAndPaginate(results []Result, defaultOrder SortOrder) []Result {
+	o.sortResults(results, defaultOrder)
+	start, end := o.page(len(results))
+	return results[start:end]
+}
+
+// page returns the start and end of the requested page, for a list of n results.
+func (o *searchOptions) page(n int) (start, end int) {
+	start = o.offset
+	if start < 0 {
+		start = 0
+	}
+	if start > n {
+		start = n
+	}
+
+	end = n
+	if o.limit > 0 && start+o.limit < n {
+		end = start + o.limit
+	}
+
+	return start, end
+}
+
+// less reports whether movie a sorts before movie b, falling back to file
+// order when they are equal.
+func (order SortOrder) less(c *collate.Collator, a, b *movie.Movie, scoreA, scoreB float64, posA, posB int) bool {
+	switch order {
+	case SortYearDesc:
+		if a.Year != b.Year {
+			return a.Year > b.Year
+		}
+	case SortYearAsc:
+		if a.Year != b.Year {
+			return a.Year < b.Year
+		}
+	case SortTitle:
+		if cmp := c.CompareString(a.Title, b.Title); cmp != 0 {
+			return cmp < 0
+		}
+	case SortScore:
+		if scoreA != scoreB {
+			return scoreA > scoreB
+		}
+	}
+	return posA < posB
+}
+
+// collator returns a collator which ignores case and accents, for SortTitle
+// only. A collator must not be shared between goroutines, so one is created
+// for each sort.
+func (order SortOrder) collator() *collate.Collator {
+	if order != SortTitle {
+		return nil
+	}
+	return collate.New(language.English, collate.Loose)
+}
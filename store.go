@@ -0,0 +1,368 @@
+package imdblit
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/mrcook/imdblit/movie"
+)
+
+// Store holds all the movies of an IMDB in memory, along with inverted indexes
+// over the normalised book titles, authors, ISBNs, movie titles, and
+// publication names, for answering repeated searches without a full scan.
+//
+// Indexed searches look up whole words, so a query such as "Aust" will not
+// find "Austen" as it would with an IMDB scan. The candidate movies are then
+// checked using the same rules as the IMDB searches.
+//
+// A Store is never modified once loaded, other than building its autocomplete
+// index on first use, which is synchronised, so it is safe for concurrent use.
+// The movies it returns share their data with the Store, and must not be modified.
+type Store struct {
+	movies []movie.Movie
+
+	// details from the DB header, used to tie an on-disk index to its source
+	crc       uint32
+	createdOn time.Time
+
+	movieTitles     index
+	bookTitles      index
+	authors         index
+	phoneticAuthors index // keyed by the phonetic code of each author name
+	isbns           index
+	publications    index
+
+	completionsOnce sync.Once
+	completions     completions
+}
+
+// NewStore processes the whole DB, returning a Store containing all its movies.
+func NewStore(db *IMDB) (*Store, error) {
+	movies, err := db.ExtractAll()
+	if err != nil {
+		return nil, err
+	}
+
+	s := newStore(movies)
+	s.crc = db.DatabaseCRC()
+	s.createdOn = db.DatabaseCreatedOn()
+
+	return s, nil
+}
+
+func newStore(movies []movie.Movie) *Store {
+	s := &Store{
+		movies:          movies,
+		movieTitles:     index{},
+		bookTitles:      index{},
+		authors:         index{},
+		phoneticAuthors: index{},
+		isbns:           index{},
+		publications:    index{},
+	}
+
+	for pos, mov := range s.movies {
+		s.movieTitles.addWords(mov.Title, pos)
+
+		for _, b := range mov.BookEntries() {
+			s.bookTitles.addWords(b.Title, pos)
+			s.authors.addWords(b.Author, pos)
+			for _, code := range movie.PhoneticWords(b.Author) {
+				s.phoneticAuthors.add(code, pos)
+			}
+			s.addISBN(b.ISBN, pos)
+		}
+		for _, p := range mov.PublicationEntries() {
+			s.publications.addWords(p.Name, pos)
+			s.addISBN(p.ISBN, pos)
+		}
+	}
+
+	return s
+}
+
+func (s *Store) addISBN(isbn movie.ISBN, pos int) {
+	if isbn, err := isbn.ISBN13(); err == nil {
+		s.isbns.add(string(isbn), pos)
+	}
+}
+
+// DatabaseCRC returns the CRC from the header of the DB the Store was loaded from.
+func (s *Store) DatabaseCRC() uint32 {
+	return s.crc
+}
+
+// DatabaseCreatedOn returns the datetime the DB the Store was loaded from was generated.
+func (s *Store) DatabaseCreatedOn() time.Time {
+	return s.createdOn
+}
+
+// Len returns the number of movies in the Store.
+func (s *Store) Len() int {
+	return len(s.movies)
+}
+
+// FindMovieAdaptations returns movies that are adaptations of the given book title/author.
+func (s *Store) FindMovieAdaptations(title, author string, opts ...SearchOption) ([]movie.Movie, error) {
+	results, err := s.FindMovieAdaptationMatches(title, author, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return resultMovies(results), nil
+}
+
+// FindMovieAdaptationMatches is the same as FindMovieAdaptations, but each
+// movie is returned with a report of the entry that matched.
+func (s *Store) FindMovieAdaptationMatches(title, author string, opts ...SearchOption) ([]Result, error) {
+	o := newSearchOptions(opts)
+	authors := s.pseudonymCandidates(author, o)
+
+	var positions [][]int
+	for _, t := range o.aliases.Titles(title) {
+		positions = append(positions, intersect(s.bookTitles.lookupWords(t), authors))
+	}
+
+	return findMovieAdaptations(s.each(union(positions...)), title, author, opts)
+}
+
+// FindAdaptationsByAuthor returns all movies that are adaptations of any work
+// by the given author, grouped by the work title.
+func (s *Store) FindAdaptationsByAuthor(author string, opts ...SearchOption) ([]AdaptedWork, error) {
+	candidates := s.pseudonymCandidates(author, newSearchOptions(opts))
+	return findAdaptationsByAuthor(s.each(candidates), author, opts)
+}
+
+// pseudonymCandidates returns the positions of the movies with an author
+// having any of the names of the author in the pseudonym registry.
+func (s *Store) pseudonymCandidates(author string, o *searchOptions) []int {
+	var positions [][]int
+	for _, name := range o.pseudonyms.Names(author) {
+		positions = append(positions, s.authorCandidates(name, o.matcher.PhoneticAuthors))
+	}
+	return union(positions...)
+}
+
+// authorCandidates returns the positions of the movies with an author having
+// all the names, or with names sounding like them when phonetic is true.
+func (s *Store) authorCandidates(author string, phonetic bool) []int {
+	if !phonetic {
+		return s.authors.lookupWords(author)
+	}
+
+	var positions [][]int
+	for _, name := range strings.Fields(author) {
+		names := s.authors.lookupWords(name)
+		if codes := movie.PhoneticWords(name); len(codes) > 0 {
+			names = union(names, s.phoneticAuthors.lookup(codes))
+		}
+		positions = append(positions, names)
+	}
+
+	return intersect(positions...)
+}
+
+// FindByISBN returns movies with a book or publication entry with the given ISBN.
+func (s *Store) FindByISBN(isbn string, opts ...SearchOption) ([]Result, error) {
+	normalised, err := movie.NormaliseISBN(isbn)
+	if err != nil {
+		return nil, err
+	}
+	return findByISBN(s.each(s.isbns.lookup([]string{normalised})), isbn, opts)
+}
+
+// FindByQuery returns all movies matching the query, in the order they
+// appear in the DB. Queries are not indexed, but are checked against the
+// movies in memory.
+func (s *Store) FindByQuery(text string, opts ...SearchOption) ([]movie.Movie, error) {
+	return findByQuery(s.each(nil), text, opts)
+}
+
+// FindPublications returns movies with publication entries matching the
+// query, along with those entries.
+func (s *Store) FindPublications(q movie.PublicationQuery, opts ...SearchOption) ([]PublicationResult, error) {
+	return findPublications(s.each(s.publications.lookupWords(q.Name)), q, opts)
+}
+
+// GroupSeries returns all TV series, with their episode records grouped by
+// season, and the literary sources of all their records merged together.
+func (s *Store) GroupSeries(opts ...SearchOption) ([]Series, error) {
+	return groupSeries(s.each(nil), opts)
+}
+
+// ClusterWorks returns all the literary works adapted by the movies, linked
+// to the movies adapting them.
+func (s *Store) ClusterWorks(opts ...SearchOption) ([]Work, error) {
+	return clusterWorks(s.each(nil), opts)
+}
+
+// FindByTitle returns all movies with a title containing the given title,
+// ignoring case, in the order they appear in the DB.
+func (s *Store) FindByTitle(title string, opts ...SearchOption) ([]movie.Movie, error) {
+	title = strings.ToLower(title)
+	return findMovies(s.each(s.movieTitles.lookupWords(title)), opts, func(mov *movie.Movie) bool {
+		return strings.Contains(strings.ToLower(mov.Title), title)
+	})
+}
+
+// each returns a func iterating over the accepted movies at the given
+// positions, or over all movies when positions is nil.
+func (s *Store) each(positions []int) eachMovie {
+	return func(accept func(*movie.Movie) bool, fn func(pos int, mov movie.Movie)) error {
+		if positions == nil {
+			for pos := range s.movies {
+				if accept(&s.movies[pos]) {
+					fn(pos, s.movies[pos])
+				}
+			}
+			return nil
+		}
+		for _, pos := range positions {
+			if accept(&s.movies[pos]) {
+				fn(pos, s.movies[pos])
+			}
+		}
+		return nil
+	}
+}
+
+// index maps a normalised word (or ISBN) to the positions of the movies that
+// contain it. Positions are always in ascending order.
+type index map[string][]int
+
+func (idx index) add(key string, pos int) {
+	positions := idx[key]
+	if len(positions) > 0 && positions[len(positions)-1] == pos {
+		return
+	}
+	idx[key] = append(positions, pos)
+}
+
+func (idx index) addWords(text string, pos int) {
+	for _, w := range words(text) {
+		idx.add(w, pos)
+	}
+}
+
+// lookup returns the positions of the movies containing all the keys, or nil
+// when there are no keys to look up, meaning all movies are candidates.
+func (idx index) lookup(keys []string) []int {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	lists := make([][]int, len(keys))
+	for i, k := range keys {
+		lists[i] = idx[k]
+		if len(lists[i]) == 0 {
+			return []int{}
+		}
+	}
+
+	return intersect(lists...)
+}
+
+func (idx index) lookupWords(text string) []int {
+	return idx.lookup(words(text))
+}
+
+// intersect returns the positions found in every list, where a nil list
+// means all positions, and is therefore ignored.
+func intersect(lists ...[]int) []int {
+	var result []int
+
+	// start with the shortest list to keep the work to a minimum
+	sort.Slice(lists, func(i, j int) bool {
+		if lists[i] == nil || lists[j] == nil {
+			return lists[j] == nil && lists[i] != nil
+		}
+		return len(lists[i]) < len(lists[j])
+	})
+
+	for i, list := range lists {
+		if list == nil {
+			break
+		}
+		if i == 0 {
+			result = list
+			continue
+		}
+
+		var common []int
+		for a, b := 0, 0; a < len(result) && b < len(list); {
+			switch {
+			case result[a] < list[b]:
+				a++
+			case result[a] > list[b]:
+				b++
+			default:
+				common = append(common, result[a])
+				a++
+				b++
+			}
+		}
+		if common == nil {
+			return []int{}
+		}
+		result = common
+	}
+
+	return result
+}
+
+// union returns the positions found in any of the lists, where a nil list
+// means all positions, so the union is also nil.
+func union(lists ...[]int) []int {
+	seen := map[int]bool{}
+	result := []int{}
+
+	for _, list := range lists {
+		if list == nil {
+			return nil
+		}
+		for _, pos := range list {
+			if !seen[pos] {
+				seen[pos] = true
+				result = append(result, pos)
+			}
+		}
+	}
+	sort.Ints(result)
+
+	return result
+}
+
+// words splits the text into lower case words, with any accents removed,
+// ignoring punctuation and the word "the", which is not used when matching titles.
+func words(text string) []string {
+	var result []string
+
+	fields := strings.FieldsFunc(strings.ToLower(foldAccents(text)), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, f := range fields {
+		if f != "the" {
+			result = append(result, f)
+		}
+	}
+
+	return result
+}
+
+// foldAccents removes any accents from the text, so that the keys of the
+// indexes and autocomplete ignore them.
+func foldAccents(text string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, text)
+	if err != nil {
+		return text
+	}
+	return folded
+}
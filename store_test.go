@@ -0,0 +1,209 @@
+package imdblit_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	imdb "github.com/mrcook/imdblit"
+	"github.com/mrcook/imdblit/movie"
+)
+
+func newTestStore(t *testing.T) *imdb.Store {
+	store, err := imdb.NewStore(imdb.NewIMDB(bytes.NewBufferString(imdbText)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return store
+}
+
+func TestStore_FindMovieAdaptations(t *testing.T) {
+	store := newTestStore(t)
+
+	if store.Len() != 5 {
+		t.Fatalf("expected 5 movies in the store, got %d", store.Len())
+	}
+
+	testItems := []struct {
+		title, author string
+		years         []int
+	}{
+		{title: "Mansfield Park", author: "Jane Austen", years: []int{2007, 1983}},
+		{title: "The Last of the Mohicans", author: "Cooper", years: []int{1971}},
+		{title: "", author: "Dixon, Stephen", years: []int{2003}},
+		{title: "Emma", author: "Jane Austen"},
+		{title: "Mansfield Park", author: "Dickens"},
+	}
+
+	for i, item := range testItems {
+		movies, err := store.FindMovieAdaptations(item.title, item.author)
+		if err != nil {
+			t.Fatalf("(#%d) unexpected error: %s", i, err)
+		}
+		if len(movies) != len(item.years) {
+			t.Fatalf("(#%d) expected %d movies to be found, got %d", i, len(item.years), len(movies))
+		}
+		for j, year := range item.years {
+			if movies[j].Year != year {
+				t.Errorf("(#%d) unexpected movie year, got: %d", i, movies[j].Year)
+			}
+		}
+	}
+}
+
+func TestStore_OtherSearches(t *testing.T) {
+	store := newTestStore(t)
+
+	results, err := store.FindByISBN("9780553213294")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 1 || results[0].Year != 1971 {
+		t.Errorf("expected the 1971 movie to be found by ISBN, got %d results", len(results))
+	}
+
+	works, err := store.FindAdaptationsByAuthor("Austen")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(works) != 1 || len(works[0].Movies) != 2 {
+		t.Errorf("expected 1 work with 2 movies to be found")
+	}
+
+	pubs, err := store.FindPublications(movie.PublicationQuery{Name: "Demonique"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(pubs) != 1 || pubs[0].Title != "Mansion of the Doomed" {
+		t.Errorf("expected Mansion of the Doomed to be found by publication")
+	}
+
+	movies, err := store.FindByTitle("mansfield")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(movies) != 2 {
+		t.Errorf("expected 2 movies to be found by title, got %d", len(movies))
+	}
+
+	movies, err = store.FindByQuery("type:CRIT OR type:PROT")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(movies) != 2 {
+		t.Errorf("expected 2 movies to be found by query, got %d", len(movies))
+	}
+}
+
+func TestStore_ConcurrentReaders(t *testing.T) {
+	store := newTestStore(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			movies, err := store.FindMovieAdaptations("Mansfield Park", "Austen")
+			if err != nil || len(movies) != 2 {
+				t.Errorf("expected 2 movies to be found, got %d (%v)", len(movies), err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestStore_PhoneticAuthors(t *testing.T) {
+	text := imdbText[:strings.Index(imdbText, "MOVI:")] + `MOVI: Vishnyovyy sad (1993)
+
+NOVL: Tchekhov, Anton. "Vishnyovyy sad"
+
+-------------------------------------------------------------------------------
+MOVI: The Cherry Orchard (1999)
+
+NOVL: Chekhov, Anton. "The Cherry Orchard"
+`
+	store, err := imdb.NewStore(imdb.NewIMDB(bytes.NewBufferString(text)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	works, err := store.FindAdaptationsByAuthor("Anton Chekhov")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(works) != 1 {
+		t.Errorf("expected 1 work without phonetic matching, got %d", len(works))
+	}
+
+	works, err = store.FindAdaptationsByAuthor("Anton Chekhov", imdb.WithPhoneticAuthors())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(works) != 2 {
+		t.Errorf("expected 2 works with phonetic matching, got %d", len(works))
+	}
+
+	db := imdb.NewIMDB(bytes.NewBufferString(text))
+	results, err := db.FindMovieAdaptationMatches("Cherry Orchard", "Tchekhov", imdb.WithPhoneticAuthors())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(results) != 1 || results[0].Match.Rules[1] != movie.AuthorPhonetic {
+		t.Errorf("expected 1 phonetic match when scanning, got %d", len(results))
+	}
+}
+
+var theWordsText = imdbText[:strings.Index(imdbText, "MOVI:")] + `MOVI: Breathe Deep (2001)
+
+NOVL: Smith, Blythe. "Breathe Deep"
+CRIT: Jones, Heather. "Deep Water". In: "Breathe" (UK), May 2001, Pg. 12, (MG)
+`
+
+func TestStore_WordsContainingThe(t *testing.T) {
+	store, err := imdb.NewStore(imdb.NewIMDB(bytes.NewBufferString(theWordsText)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testItems := []struct {
+		title, author string
+	}{
+		{title: "Deep", author: "Blythe"},
+		{title: "Breathe Deep", author: "Smith, Blythe"},
+		{title: "Breathe", author: "Blythe Smith"},
+	}
+
+	for i, item := range testItems {
+		scanned, err := imdb.NewIMDB(bytes.NewBufferString(theWordsText)).FindMovieAdaptations(item.title, item.author)
+		if err != nil {
+			t.Fatalf("(#%d) unexpected error: %s", i, err)
+		}
+		stored, err := store.FindMovieAdaptations(item.title, item.author)
+		if err != nil {
+			t.Fatalf("(#%d) unexpected error: %s", i, err)
+		}
+		if len(scanned) != 1 || len(stored) != len(scanned) {
+			t.Errorf("(#%d) expected the store to find the same movie as the scan, got %d and %d", i, len(stored), len(scanned))
+		}
+	}
+
+	q := movie.PublicationQuery{Name: "Breathe", Author: "Heather Jones"}
+	scanned, err := imdb.NewIMDB(bytes.NewBufferString(theWordsText)).FindPublications(q)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	stored, err := store.FindPublications(q)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(scanned) != 1 || len(stored) != len(scanned) {
+		t.Errorf("expected the store to find the same publication as the scan, got %d and %d", len(stored), len(scanned))
+	}
+
+	for i, prefix := range []string{"breath", "blyth", "smith bly"} {
+		if suggestions := store.Autocomplete(prefix, 5, imdb.SuggestBookTitle, imdb.SuggestAuthor); len(suggestions) != 1 {
+			t.Errorf("(#%d) expected a suggestion for '%s', got %+v", i, prefix, suggestions)
+		}
+	}
+}
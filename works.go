@@ -0,0 +1,82 @@
+package imdblit
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/mrcook/imdblit/movie"
+)
+
+// Work is a canonical literary work, clustering all the book entries (ADPT,
+// BOOK, NOVL) with the same normalised title and author, whatever their
+// publisher, edition, or ISBN.
+type Work struct {
+	Title  string // as given by the first entry
+	Author string // the author names of the first entry, without any editors or translators
+
+	Entries []WorkEntry // all the entries for the work, in file order
+	Movies  []Result    // the movies adapting the work, with Match giving the first entry for it
+}
+
+// WorkEntry is a book entry of a work, along with the position of the record
+// it is from.
+type WorkEntry struct {
+	movie.BookEntry
+	Position int
+}
+
+// clusterWorks sorts the works by their normalised title and author, with the
+// pagination applied to the works, and the sort order to the movies of each work.
+func clusterWorks(each eachMovie, opts []SearchOption) ([]Work, error) {
+	var works []Work
+	var keys []string
+	workIndex := map[string]int{}
+
+	o := newSearchOptions(opts)
+	err := each(o.accept, func(pos int, mov movie.Movie) {
+		seen := map[int]bool{}
+		for _, b := range mov.BookEntries() {
+			if len(aliasKey(b.Title)) == 0 {
+				continue
+			}
+			key := workKey(&b.Book)
+			i, ok := workIndex[key]
+			if !ok {
+				i = len(works)
+				workIndex[key] = i
+				keys = append(keys, key)
+				works = append(works, Work{Title: b.Title, Author: strings.Join(b.AuthorNames(), " & ")})
+			}
+
+			w := &works[i]
+			w.Entries = append(w.Entries, WorkEntry{BookEntry: b, Position: pos})
+			if !seen[i] {
+				seen[i] = true
+				match := movie.Match{Entry: b.Entry, Index: b.Index}
+				w.Movies = append(w.Movies, Result{Movie: mov, Match: match, Position: pos})
+			}
+		}
+	})
+
+	sort.Sort(worksByKey{works: works, keys: keys})
+
+	for i := range works {
+		o.sortResults(works[i].Movies, SortYearDesc)
+	}
+	start, end := o.page(len(works))
+
+	return works[start:end], err
+}
+
+// worksByKey sorts works by their normalised title and author.
+type worksByKey struct {
+	works []Work
+	keys  []string
+}
+
+func (w worksByKey) Len() int           { return len(w.works) }
+func (w worksByKey) Less(i, j int) bool { return w.keys[i] < w.keys[j] }
+func (w worksByKey) Swap(i, j int) {
+	w.works[i], w.works[j] = w.works[j], w.works[i]
+	w.keys[i], w.keys[j] = w.keys[j], w.keys[i]
+}
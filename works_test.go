@@ -0,0 +1,127 @@
+package imdblit_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	imdb "github.com/mrcook/imdblit"
+	"github.com/mrcook/imdblit/movie"
+)
+
+var worksText = imdbText[:strings.Index(imdbText, "MOVI:")] + `MOVI: Emma (1996)
+
+NOVL: Austen, Jane. "Emma". Penguin, London, 1985, ISBN-10: 0140430105
+BOOK: Austen, Jane. "Emma"
+
+-------------------------------------------------------------------------------
+MOVI: Clueless (1995)
+
+ADPT: Jane Austen. "Emma". Oxford University Press, 2003
+
+-------------------------------------------------------------------------------
+MOVI: Persuasion (1995) (TV)
+
+NOVL: Austen, Jane. "Persuasion"
+`
+
+func TestClusterWorks(t *testing.T) {
+	db := imdb.NewIMDB(bytes.NewBufferString(worksText))
+	works, err := db.ClusterWorks()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(works) != 2 {
+		t.Fatalf("expected 2 works, got %d", len(works))
+	}
+
+	emma := works[0]
+	if emma.Title != "Emma" || emma.Author != "Austen, Jane" {
+		t.Errorf("expected Emma by Austen, got '%s' by '%s'", emma.Title, emma.Author)
+	}
+	if len(emma.Entries) != 3 {
+		t.Errorf("expected 3 entries, got %d", len(emma.Entries))
+	}
+	if len(emma.Movies) != 2 {
+		t.Fatalf("expected 2 movies, got %d", len(emma.Movies))
+	}
+	if emma.Movies[0].Title != "Emma" || emma.Movies[0].Match.Entry != movie.BOOK {
+		t.Errorf("expected the newest movie first, with its first entry, got '%s' %s", emma.Movies[0].Title, emma.Movies[0].Match.Entry)
+	}
+
+	if works[1].Title != "Persuasion" {
+		t.Errorf("expected Persuasion, got '%s'", works[1].Title)
+	}
+}
+
+func TestClusterWorksStore(t *testing.T) {
+	store, err := imdb.NewStore(imdb.NewIMDB(bytes.NewBufferString(worksText)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	works, err := store.ClusterWorks(imdb.WithTV(false), imdb.WithPagination(0, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(works) != 1 || works[0].Title != "Emma" {
+		t.Fatalf("expected only the Emma work, got %d works", len(works))
+	}
+}
+
+func TestClusterWorksContributors(t *testing.T) {
+	text := imdbText[:strings.Index(imdbText, "MOVI:")] + `MOVI: Don Quixote (2000) (TV)
+
+NOVL: Cervantes, Miguel de. "Don Quixote"
+
+-------------------------------------------------------------------------------
+MOVI: Lost in La Mancha (2002)
+
+ADPT: Cervantes, Miguel de & Grossman, Edith (trans.). "Don Quixote"
+`
+
+	store, err := imdb.NewStore(imdb.NewIMDB(bytes.NewBufferString(text)))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	works, err := store.ClusterWorks()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(works) != 1 {
+		t.Fatalf("expected the translated edition to be the same work, got %d works", len(works))
+	}
+	if works[0].Author != "Cervantes, Miguel de" || len(works[0].Movies) != 2 {
+		t.Errorf("expected 2 movies by Cervantes, got %d by '%s'", len(works[0].Movies), works[0].Author)
+	}
+
+	got := store.Autocomplete("cervantes", 5, imdb.SuggestAuthor)
+	if len(got) != 1 || got[0].Text != "Cervantes, Miguel de" || got[0].Count != 2 {
+		t.Errorf("expected only the author name to be suggested, got %+v", got)
+	}
+	if got = store.Autocomplete("grossman", 5, imdb.SuggestAuthor); len(got) != 0 {
+		t.Errorf("expected the translator to not be suggested as an author, got %+v", got)
+	}
+}
+
+func TestClusterWorksNameOrder(t *testing.T) {
+	text := imdbText[:strings.Index(imdbText, "MOVI:")] + `MOVI: Breathe Deep (2001)
+
+NOVL: Smith, Heather. "Breathe Deep"
+
+-------------------------------------------------------------------------------
+MOVI: Breathe Deeper (2005)
+
+ADPT: Heather Smith. "Breathe Deep"
+`
+
+	works, err := imdb.NewIMDB(bytes.NewBufferString(text)).ClusterWorks()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(works) != 1 || len(works[0].Movies) != 2 {
+		t.Fatalf("expected 1 work with 2 movies, whatever the author name order, got %d works", len(works))
+	}
+}